@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ACLTag is the type of an ACLEntry.
+// The values match the ACL_* tags used by Linux.
+type ACLTag uint16
+
+const (
+	// ACLUserObj is the permissions of the file's owner.
+	ACLUserObj ACLTag = 0x01
+	// ACLUser is the permissions of the user with the entry's ID.
+	ACLUser ACLTag = 0x02
+	// ACLGroupObj is the permissions of the file's group.
+	ACLGroupObj ACLTag = 0x04
+	// ACLGroup is the permissions of the group with the entry's ID.
+	ACLGroup ACLTag = 0x08
+	// ACLMask is the maximum permissions granted by the ACLUser, ACLGroupObj,
+	// and ACLGroup entries.
+	ACLMask ACLTag = 0x10
+	// ACLOther is the permissions of everyone else.
+	ACLOther ACLTag = 0x20
+)
+
+// An ACLEntry is a single entry of an [ACL].
+type ACLEntry struct {
+	Tag  ACLTag      // the entry's type
+	ID   int         // the user or group ID, for ACLUser and ACLGroup entries, otherwise UnknownID
+	Perm os.FileMode // the permissions, a combination of 0o4 (read), 0o2 (write), and 0o1 (execute)
+}
+
+// An ACL is a POSIX access control list.
+type ACL []ACLEntry
+
+const (
+	aclXattrAccess  = "system.posix_acl_access"
+	aclXattrDefault = "system.posix_acl_default"
+
+	aclVersion     = 2
+	aclUndefinedID = 0xffffffff
+	aclHeaderSize  = 4
+	aclEntrySize   = 8
+	aclPermMask    = 0o7
+)
+
+// ErrInvalidACL is returned if an ACL is not valid.
+var ErrInvalidACL = errors.New("invalid ACL")
+
+// ACLFromMode returns the minimal ACL, with ACLUserObj, ACLGroupObj, and
+// ACLOther entries, equivalent to the permission bits of mode.
+func ACLFromMode(mode os.FileMode) ACL {
+	return ACL{
+		{Tag: ACLUserObj, ID: UnknownID, Perm: mode >> 6 & aclPermMask},
+		{Tag: ACLGroupObj, ID: UnknownID, Perm: mode >> 3 & aclPermMask},
+		{Tag: ACLOther, ID: UnknownID, Perm: mode & aclPermMask},
+	}
+}
+
+// Mode returns the permission bits equivalent to acl. As in POSIX, the
+// group bits are the ACLMask entry's permissions, if there is one,
+// otherwise, the ACLGroupObj entry's permissions.
+func (acl ACL) Mode() os.FileMode {
+	var user, group, mask, other os.FileMode
+
+	hasMask := false
+
+	for _, e := range acl {
+		switch e.Tag {
+		case ACLUserObj:
+			user = e.Perm & aclPermMask
+		case ACLGroupObj:
+			group = e.Perm & aclPermMask
+		case ACLMask:
+			mask = e.Perm & aclPermMask
+			hasMask = true
+		case ACLOther:
+			other = e.Perm & aclPermMask
+		case ACLUser, ACLGroup:
+			// not represented in the permission bits
+		}
+	}
+
+	if hasMask {
+		group = mask
+	}
+
+	return user<<6 | group<<3 | other
+}
+
+// IsMinimal returns true if acl only has ACLUserObj, ACLGroupObj, and
+// ACLOther entries, so it is fully represented by the permission bits.
+func (acl ACL) IsMinimal() bool {
+	for _, e := range acl {
+		if e.Tag != ACLUserObj && e.Tag != ACLGroupObj && e.Tag != ACLOther {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate returns an error wrapping [ErrInvalidACL] if acl doesn't have
+// exactly one ACLUserObj, ACLGroupObj, and ACLOther entry, has an
+// ACLUser or ACLGroup entry but no ACLMask entry, or has more than one entry
+// for the same user or group.
+func (acl ACL) Validate() error {
+	counts := map[ACLTag]int{}
+	ids := map[ACLEntry]bool{}
+
+	for _, e := range acl {
+		switch e.Tag {
+		case ACLUserObj, ACLGroupObj, ACLMask, ACLOther:
+			counts[e.Tag]++
+			if counts[e.Tag] > 1 {
+				return fmt.Errorf("%w: duplicate %v entry", ErrInvalidACL, e.Tag.name())
+			}
+		case ACLUser, ACLGroup:
+			if e.ID < 0 || uint64(e.ID) >= aclUndefinedID {
+				return fmt.Errorf("%w: invalid ID %d in %v entry", ErrInvalidACL, e.ID, e.Tag.name())
+			}
+
+			key := ACLEntry{Tag: e.Tag, ID: e.ID}
+			if ids[key] {
+				return fmt.Errorf("%w: duplicate %v entry for ID %d", ErrInvalidACL, e.Tag.name(), e.ID)
+			}
+
+			ids[key] = true
+		default:
+			return fmt.Errorf("%w: unknown tag 0x%x", ErrInvalidACL, uint16(e.Tag))
+		}
+	}
+
+	for _, tag := range []ACLTag{ACLUserObj, ACLGroupObj, ACLOther} {
+		if counts[tag] != 1 {
+			return fmt.Errorf("%w: missing %v entry", ErrInvalidACL, tag.name())
+		}
+	}
+
+	if len(ids) > 0 && counts[ACLMask] == 0 {
+		return fmt.Errorf("%w: missing %v entry", ErrInvalidACL, ACLMask.name())
+	}
+
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. It returns acl in
+// the format of Linux's system.posix_acl_access and system.posix_acl_default
+// extended attributes, with the entries sorted, as the kernel requires.
+func (acl ACL) MarshalBinary() ([]byte, error) {
+	err := acl.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := slices.Clone(acl)
+	slices.SortFunc(sorted, func(a, b ACLEntry) int {
+		return cmp.Or(cmp.Compare(a.Tag, b.Tag), cmp.Compare(a.ID, b.ID))
+	})
+
+	data := make([]byte, aclHeaderSize, aclHeaderSize+len(sorted)*aclEntrySize)
+	binary.LittleEndian.PutUint32(data, aclVersion)
+
+	for _, e := range sorted {
+		id := uint32(aclUndefinedID)
+		if e.Tag == ACLUser || e.Tag == ACLGroup {
+			id = uint32(e.ID) //nolint:gosec // validated above
+		}
+
+		data = binary.LittleEndian.AppendUint16(data, uint16(e.Tag))
+		data = binary.LittleEndian.AppendUint16(data, uint16(e.Perm&aclPermMask))
+		data = binary.LittleEndian.AppendUint32(data, id)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler]. It parses data
+// in the format of Linux's system.posix_acl_access and
+// system.posix_acl_default extended attributes.
+func (acl *ACL) UnmarshalBinary(data []byte) error {
+	if len(data) < aclHeaderSize || (len(data)-aclHeaderSize)%aclEntrySize != 0 {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidACL, len(data))
+	}
+
+	version := binary.LittleEndian.Uint32(data)
+	if version != aclVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidACL, version)
+	}
+
+	entries := make(ACL, 0, (len(data)-aclHeaderSize)/aclEntrySize)
+
+	for i := aclHeaderSize; i < len(data); i += aclEntrySize {
+		e := ACLEntry{
+			Tag:  ACLTag(binary.LittleEndian.Uint16(data[i:])),
+			Perm: os.FileMode(binary.LittleEndian.Uint16(data[i+2:])),
+			ID:   UnknownID,
+		}
+
+		if e.Tag == ACLUser || e.Tag == ACLGroup {
+			e.ID = int(binary.LittleEndian.Uint32(data[i+4:])) //nolint:gosec // intentional uint32 → int conversion
+		}
+
+		entries = append(entries, e)
+	}
+
+	*acl = entries
+
+	return nil
+}
+
+// String returns acl in the short text form used by getfacl(1) and
+// setfacl(1), for example "u::rw-,u:1000:r--,g::r--,m::r--,o::---".
+func (acl ACL) String() string {
+	parts := make([]string, 0, len(acl))
+
+	for _, e := range acl {
+		parts = append(parts, e.String())
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// String returns e in the short text form used by getfacl(1) and
+// setfacl(1), for example "u:1000:r--".
+func (e ACLEntry) String() string {
+	id := ""
+	if e.Tag == ACLUser || e.Tag == ACLGroup {
+		id = strconv.Itoa(e.ID)
+	}
+
+	perm := []byte("---")
+	for i, c := range "rwx" {
+		if e.Perm&(0o4>>i) != 0 {
+			perm[i] = byte(c)
+		}
+	}
+
+	return e.Tag.String() + ":" + id + ":" + string(perm)
+}
+
+// String returns the tag's short text form, as used by getfacl(1).
+func (t ACLTag) String() string {
+	switch t {
+	case ACLUserObj, ACLUser:
+		return "u"
+	case ACLGroupObj, ACLGroup:
+		return "g"
+	case ACLMask:
+		return "m"
+	case ACLOther:
+		return "o"
+	default:
+		return "0x" + strconv.FormatUint(uint64(t), 16)
+	}
+}
+
+// name returns the tag's name, as used by acl(5).
+func (t ACLTag) name() string {
+	switch t {
+	case ACLUserObj:
+		return "ACL_USER_OBJ"
+	case ACLUser:
+		return "ACL_USER"
+	case ACLGroupObj:
+		return "ACL_GROUP_OBJ"
+	case ACLGroup:
+		return "ACL_GROUP"
+	case ACLMask:
+		return "ACL_MASK"
+	case ACLOther:
+		return "ACL_OTHER"
+	default:
+		return t.String()
+	}
+}
+
+// GetACL returns the access ACL of the named file. If the file has no
+// extended ACL, the minimal ACL equivalent to its permission bits is
+// returned.
+// ACLs are supported on Linux only. On other operating systems, the error
+// wraps an *UnsupportedError.
+// If there is an error, it will be of type [*PathError].
+func GetACL(name string) (ACL, error) {
+	return getACL(name, aclXattrAccess)
+}
+
+// SetACL sets the access ACL of the named file. This also sets the file's
+// permission bits, see ACL's Mode() function.
+// If there is an error, it will be of type [*PathError].
+func SetACL(name string, acl ACL) error {
+	return setACL(name, aclXattrAccess, acl)
+}
+
+// GetDefaultACL returns the default ACL of the named directory, which is
+// inherited by the files and directories created in it, or nil if it has
+// none.
+// If there is an error, it will be of type [*PathError].
+func GetDefaultACL(name string) (ACL, error) {
+	return getACL(name, aclXattrDefault)
+}
+
+// SetDefaultACL sets the default ACL of the named directory. If acl is
+// empty, the default ACL is removed.
+// If there is an error, it will be of type [*PathError].
+func SetDefaultACL(name string, acl ACL) error {
+	return setACL(name, aclXattrDefault, acl)
+}
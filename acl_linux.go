@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package compat
+
+import (
+	"errors"
+	"os"
+)
+
+func getACL(name, xattr string) (ACL, error) {
+	data, err := GetXattr(name, xattr)
+	if errors.Is(err, ErrNoXattr) {
+		if xattr == aclXattrDefault {
+			return nil, nil
+		}
+
+		fi, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return ACLFromMode(fi.Mode()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var acl ACL
+
+	err = acl.UnmarshalBinary(data)
+	if err != nil {
+		return nil, &os.PathError{Op: "getacl", Path: name, Err: err}
+	}
+
+	return acl, nil
+}
+
+func setACL(name, xattr string, acl ACL) error {
+	if len(acl) == 0 && xattr == aclXattrDefault {
+		err := RemoveXattr(name, xattr)
+		if errors.Is(err, ErrNoXattr) {
+			return nil
+		}
+
+		return err
+	}
+
+	data, err := acl.MarshalBinary()
+	if err != nil {
+		return &os.PathError{Op: "setacl", Path: name, Err: err}
+	}
+
+	return SetXattr(name, xattr, data, 0)
+}
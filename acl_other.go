@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+
+package compat
+
+import (
+	"os"
+)
+
+func getACL(name, _ string) (ACL, error) {
+	return nil, &os.PathError{Op: "getacl", Path: name, Err: &UnsupportedError{Op: "getacl"}}
+}
+
+func setACL(name, _ string, _ ACL) error {
+	return &os.PathError{Op: "setacl", Path: name, Err: &UnsupportedError{Op: "setacl"}}
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+var testACL = compat.ACL{
+	{Tag: compat.ACLOther, ID: compat.UnknownID, Perm: 0o0},
+	{Tag: compat.ACLUser, ID: 2000, Perm: 0o4},
+	{Tag: compat.ACLUserObj, ID: compat.UnknownID, Perm: 0o6},
+	{Tag: compat.ACLGroupObj, ID: compat.UnknownID, Perm: 0o4},
+	{Tag: compat.ACLMask, ID: compat.UnknownID, Perm: 0o6},
+	{Tag: compat.ACLUser, ID: 1000, Perm: 0o6},
+}
+
+var testACLBinary = []byte{
+	2, 0, 0, 0, // version
+	0x01, 0, 6, 0, 0xff, 0xff, 0xff, 0xff, // user_obj rw-
+	0x02, 0, 6, 0, 0xe8, 0x03, 0, 0, // user 1000 rw-
+	0x02, 0, 4, 0, 0xd0, 0x07, 0, 0, // user 2000 r--
+	0x04, 0, 4, 0, 0xff, 0xff, 0xff, 0xff, // group_obj r--
+	0x10, 0, 6, 0, 0xff, 0xff, 0xff, 0xff, // mask rw-
+	0x20, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, // other ---
+}
+
+func TestACLFromMode(t *testing.T) {
+	acl := compat.ACLFromMode(0o754)
+
+	if got := acl.Mode(); got != 0o754 {
+		t.Fatalf("Mode(): got 0o%o, want 0o%o", got, 0o754)
+	}
+
+	if !acl.IsMinimal() {
+		t.Fatal("IsMinimal(): got false, want true")
+	}
+
+	if got, want := acl.String(), "u::rwx,g::r-x,o::r--"; got != want {
+		t.Fatalf("String(): got %v, want %v", got, want)
+	}
+}
+
+func TestACLMode(t *testing.T) {
+	if got := testACL.Mode(); got != 0o660 {
+		t.Fatalf("Mode(): got 0o%o, want 0o%o", got, 0o660)
+	}
+
+	if testACL.IsMinimal() {
+		t.Fatal("IsMinimal(): got true, want false")
+	}
+}
+
+func TestACLMarshalBinary(t *testing.T) {
+	data, err := testACL.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, testACLBinary) {
+		t.Fatalf("MarshalBinary():\ngot  %v\nwant %v", data, testACLBinary)
+	}
+
+	var acl compat.ACL
+
+	err = acl.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := acl.String(), "u::rw-,u:1000:rw-,u:2000:r--,g::r--,m::rw-,o::---"; got != want {
+		t.Fatalf("UnmarshalBinary(): got %v, want %v", got, want)
+	}
+
+	for _, data := range [][]byte{nil, testACLBinary[:7], append([]byte{1}, testACLBinary[1:]...)} {
+		err = acl.UnmarshalBinary(data)
+		if !errors.Is(err, compat.ErrInvalidACL) {
+			t.Fatalf("UnmarshalBinary(%v): got %v, want %v", data, err, compat.ErrInvalidACL)
+		}
+	}
+}
+
+func TestACLValidate(t *testing.T) {
+	userObj := compat.ACLEntry{Tag: compat.ACLUserObj, ID: compat.UnknownID, Perm: 0o6}
+	groupObj := compat.ACLEntry{Tag: compat.ACLGroupObj, ID: compat.UnknownID, Perm: 0o4}
+	other := compat.ACLEntry{Tag: compat.ACLOther, ID: compat.UnknownID}
+	mask := compat.ACLEntry{Tag: compat.ACLMask, ID: compat.UnknownID, Perm: 0o4}
+	user := compat.ACLEntry{Tag: compat.ACLUser, ID: 1000, Perm: 0o4}
+
+	tests := []struct {
+		name  string
+		acl   compat.ACL
+		valid bool
+	}{
+		{"minimal", compat.ACL{userObj, groupObj, other}, true},
+		{"named", compat.ACL{userObj, user, groupObj, mask, other}, true},
+		{"empty", compat.ACL{}, false},
+		{"missing other", compat.ACL{userObj, groupObj}, false},
+		{"duplicate user_obj", compat.ACL{userObj, userObj, groupObj, other}, false},
+		{"missing mask", compat.ACL{userObj, user, groupObj, other}, false},
+		{"duplicate user", compat.ACL{userObj, user, user, groupObj, mask, other}, false},
+		{"negative ID", compat.ACL{userObj, {Tag: compat.ACLGroup, ID: -1}, groupObj, mask, other}, false},
+		{"unknown tag", compat.ACL{userObj, {Tag: 0x40}, groupObj, other}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.acl.Validate()
+			if tt.valid && err != nil {
+				t.Fatalf("Validate(): got %v, want nil", err)
+			}
+
+			if !tt.valid && !errors.Is(err, compat.ErrInvalidACL) {
+				t.Fatalf("Validate(): got %v, want %v", err, compat.ErrInvalidACL)
+			}
+		})
+	}
+}
+
+func TestGetSetACL(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chmod(name, 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acl, err := compat.GetACL(name)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: ACLs not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := acl.String(), "u::rw-,g::r--,o::---"; got != want {
+		t.Fatalf("GetACL(): got %v, want %v", got, want)
+	}
+
+	err = compat.SetACL(name, testACL)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: ACLs not supported by the filesystem: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acl, err = compat.GetACL(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := acl.String(), "u::rw-,u:1000:rw-,u:2000:r--,g::r--,m::rw-,o::---"; got != want {
+		t.Fatalf("GetACL(): got %v, want %v", got, want)
+	}
+
+	fi, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.Mode().Perm(); got != 0o660 {
+		t.Fatalf("Mode(): got 0o%o, want 0o%o", got, 0o660)
+	}
+}
+
+func TestGetSetDefaultACL(t *testing.T) {
+	dir := tempDir(t)
+
+	acl, err := compat.GetDefaultACL(dir)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: ACLs not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if acl != nil {
+		t.Fatalf("GetDefaultACL(): got %v, want nil", acl)
+	}
+
+	err = compat.SetDefaultACL(dir, testACL)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: ACLs not supported by the filesystem: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Files created in the directory inherit the default ACL.
+	name := filepath.Join(dir, "inherited.txt")
+
+	err = os.WriteFile(name, helloBytes, 0o666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acl, err = compat.GetACL(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := acl.String(), "u::rw-,u:1000:rw-,u:2000:r--,g::r--,m::rw-,o::---"; got != want {
+		t.Fatalf("GetACL(): got %v, want %v", got, want)
+	}
+
+	err = compat.SetDefaultACL(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acl, err = compat.GetDefaultACL(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if acl != nil {
+		t.Fatalf("GetDefaultACL(): got %v, want nil", acl)
+	}
+}
+
+func TestSetACLInvalid(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.SetACL(name, compat.ACL{})
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: ACLs not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if !errors.Is(err, compat.ErrInvalidACL) {
+		t.Fatalf("SetACL(): got %v, want %v", err, compat.ErrInvalidACL)
+	}
+}
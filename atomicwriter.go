@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rasa/compat/golang"
+)
+
+// atomicTempPattern is the default pattern of the temporary file names.
+const atomicTempPattern = "~*.tmp"
+
+// tempPattern returns the pattern of the temporary file names.
+func tempPattern(options *Options) string {
+	if options.tempPattern == "" {
+		return atomicTempPattern
+	}
+
+	return options.tempPattern
+}
+
+// errPatternNoLiteral is returned for a temp pattern, such as "*", that has
+// no prefix, or suffix.
+var errPatternNoLiteral = errors.New("pattern has no prefix or suffix")
+
+// tempPrefixAndSuffix splits the temp pattern at its last "*". A pattern
+// without a prefix, or a suffix, is rejected, as CleanupStaleTemps couldn't
+// tell its temporary files from other files with decimal names.
+func tempPrefixAndSuffix(pattern string) (string, string, error) {
+	prefix, suffix, err := golang.PrefixAndSuffix(pattern)
+	if err == nil && prefix == "" && suffix == "" {
+		err = errPatternNoLiteral
+	}
+
+	return prefix, suffix, err
+}
+
+// AtomicWriter is an io.Writer that writes to a temporary file, in the same
+// directory as the named file, and atomically replaces the named file with
+// it when Commit is called. Until then, the named file is unchanged.
+// On Linux, the temporary file is an unnamed O_TMPFILE file, which is only
+// linked into the directory when Commit renames it, so a crash doesn't leave
+// a temporary file behind. The O_APPEND, O_SYNC, O_DSYNC and O_NOATIME
+// flags are passed to open(2). If WithFlags includes any other flags, other
+// than the access mode, O_CREATE, O_TRUNC and O_EXCL, a named temporary file
+// is used instead.
+// It is not safe for concurrent use.
+type AtomicWriter struct {
+	file     *os.File
+	name     string
+	dir      string
+	tempName string // empty until an unnamed file is linked
+	unnamed  bool
+	fopts    Options
+	done     bool
+}
+
+// NewAtomicWriter returns an AtomicWriter that replaces the named file,
+// creating it if necessary, when Commit is called. Close, or Abort, discards
+// the written data, unless Commit was called first, so the usual pattern is:
+//
+//	w, err := compat.NewAtomicWriter(name, 0o644)
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+//
+//	err = json.NewEncoder(w).Encode(v)
+//	if err != nil {
+//		return err
+//	}
+//
+//	return w.Commit()
+//
+// The perm argument, and the options, are handled as they are by
+// WriteReader with the WithAtomicity(true) option, including the
+// WithKeepFileMode, WithReadOnlyMode, WithDurability and WithContext
+// options. If the context is cancelled, Write and Commit return an error.
+//
+// On Plan 9, if the named file exists, Commit returns an error matching
+// errors.ErrUnsupported, unless the WithNonAtomicReplace option is passed.
+// If there is an error, it will be of type [*PathError].
+func NewAtomicWriter(name string, perm os.FileMode, opts ...Option) (*AtomicWriter, error) {
+	fopts, fileMode, err := writeOptions(name, perm, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAtomicWriter(name, fileMode, fopts)
+}
+
+func newAtomicWriter(name string, fileMode os.FileMode, fopts Options) (*AtomicWriter, error) {
+	dir, _ := filepath.Split(name)
+	if dir == "" {
+		dir = "."
+	}
+
+	pattern := tempPattern(&fopts)
+
+	// check the pattern now, as an unnamed file isn't named until Commit.
+	_, _, err := tempPrefixAndSuffix(pattern)
+	if err != nil {
+		return nil, writeError(name, createTempError(pattern, err))
+	}
+
+	file, unnamed, err := createAtomicTemp(dir, pattern, fileMode, fopts.flags)
+	if err != nil {
+		err = fmt.Errorf("cannot create tempfile: %w", err)
+
+		return nil, writeError(name, err)
+	}
+
+	w := &AtomicWriter{
+		file:    file,
+		name:    name,
+		dir:     dir,
+		unnamed: unnamed,
+		fopts:   fopts,
+	}
+
+	if !unnamed {
+		w.tempName = file.Name()
+	}
+
+	return w, nil
+}
+
+// Name returns the name of the file that Commit replaces.
+func (w *AtomicWriter) Name() string {
+	return w.name
+}
+
+// Write writes p to the temporary file.
+// After Commit, Abort, or Close, it returns an error matching os.ErrClosed.
+func (w *AtomicWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, writeError(w.name, os.ErrClosed)
+	}
+
+	err := contextErr(w.fopts.ctx)
+	if err != nil {
+		return 0, writeError(w.name, err)
+	}
+
+	return w.file.Write(p)
+}
+
+// Commit syncs, and closes, the temporary file, and renames it to the named
+// file. If Commit fails, the temporary file is removed, and the named file
+// is unchanged.
+// After Commit, Abort, or Close, it returns an error matching os.ErrClosed.
+func (w *AtomicWriter) Commit() (err error) {
+	if w.done {
+		return writeError(w.name, os.ErrClosed)
+	}
+
+	w.done = true
+
+	defer func() {
+		if err != nil {
+			// Don't leave the temp file lying around on error.
+			_ = w.remove()
+		}
+	}()
+
+	// fsync is important, otherwise os.Rename could rename a zero-length file
+	err = w.file.Sync()
+	if err != nil {
+		err = fmt.Errorf("cannot sync '%v': %w", w.file.Name(), err)
+
+		return writeError(w.name, err)
+	}
+
+	if w.unnamed {
+		// give the file a short-lived name, so it can be renamed over the
+		// destination, as linkat(2) can't replace an existing file.
+		w.tempName, err = linkAtomicTemp(w.file, w.dir, tempPattern(&w.fopts))
+		if err != nil {
+			err = fmt.Errorf("cannot link tempfile: %w", err)
+
+			return writeError(w.name, err)
+		}
+	}
+
+	tempFileName := w.tempName
+
+	err = w.file.Close()
+	if err != nil {
+		err = fmt.Errorf("cannot close '%v': %w", tempFileName, err)
+
+		return writeError(w.name, err)
+	}
+
+	// don't replace the destination if the write was cancelled after the
+	// last write.
+	err = contextErr(w.fopts.ctx)
+	if err != nil {
+		return writeError(w.name, err)
+	}
+
+	err = Rename(
+		tempFileName,
+		w.name,
+		WithNonAtomicReplace(w.fopts.nonAtomicReplace),
+		WithDurability(w.fopts.durable),
+	)
+	if err != nil {
+		err = fmt.Errorf("cannot rename to '%v': %w", tempFileName, err)
+
+		return writeError(w.name, err)
+	}
+
+	return nil
+}
+
+// Abort closes, and removes, the temporary file, leaving the named file
+// unchanged. After Commit, Abort, or Close, it does nothing.
+func (w *AtomicWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+
+	w.done = true
+
+	return w.remove()
+}
+
+// Close discards the written data, as Abort does, unless Commit was called
+// first. It lets an AtomicWriter be used with defer, and as an io.WriteCloser.
+func (w *AtomicWriter) Close() error {
+	return w.Abort()
+}
+
+// remove closes, and removes, the temporary file. An unnamed file that
+// hasn't been linked is discarded when it is closed.
+func (w *AtomicWriter) remove() error {
+	_ = w.file.Close()
+
+	name := w.tempName
+	if name == "" {
+		return nil
+	}
+
+	_ = Chmod(name, CreateTempPerm) // 0o600
+
+	err := Remove(name)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/rasa/compat/golang"
+)
+
+// procSelfFD is the directory linkat(2) reads an O_TMPFILE file's
+// descriptor from, as AT_EMPTY_PATH requires CAP_DAC_READ_SEARCH.
+const procSelfFD = "/proc/self/fd"
+
+// tmpFileFlags are the flags openTmpFileOS passes to open(2).
+const tmpFileFlags = unix.O_APPEND | unix.O_SYNC | unix.O_DSYNC | unix.O_NOATIME
+
+// tmpFileIgnored are the flags that don't apply to a new unnamed file. The
+// access mode is always O_RDWR, and O_EXCL would stop Commit linking it.
+const tmpFileIgnored = unix.O_ACCMODE | unix.O_CREAT | unix.O_TRUNC | unix.O_EXCL
+
+// openTmpFile opens an unnamed file in dir. Tests replace it to force the
+// fallback to a named temporary file.
+var openTmpFile = openTmpFileOS
+
+// createAtomicTemp opens an unnamed O_TMPFILE file in dir, so no temporary
+// file is visible until Commit links it into dir, just before renaming it.
+// If the filesystem doesn't support O_TMPFILE, /proc isn't mounted, or flag
+// includes flags that an O_TMPFILE file can't honour, such as
+// O_FILE_FLAG_DELETE_ON_CLOSE, it creates a named temporary file, and
+// returns false.
+func createAtomicTemp(dir, pattern string, perm os.FileMode, flag int) (*os.File, bool, error) {
+	_, err := os.Stat(procSelfFD)
+	if err == nil && flag&^(tmpFileFlags|tmpFileIgnored) == 0 {
+		var f *os.File
+
+		f, err = openTmpFile(dir, perm, flag)
+		if err == nil {
+			return f, true, nil
+		}
+
+		if !isTmpFileUnsupported(err) {
+			return nil, false, err
+		}
+	}
+
+	f, err := createTemp(dir, pattern, perm, flag)
+
+	return f, false, err
+}
+
+func openTmpFileOS(dir string, perm os.FileMode, flag int) (*os.File, error) {
+	flag = flag&tmpFileFlags | unix.O_TMPFILE | unix.O_RDWR | unix.O_CLOEXEC
+
+	fd, err := unix.Open(dir, flag, uint32(perm.Perm()))
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+
+	return os.NewFile(uintptr(fd), dir), nil
+}
+
+// isTmpFileUnsupported returns true if the filesystem doesn't support
+// O_TMPFILE (EOPNOTSUPP), or the kernel predates it (EISDIR, see open(2)).
+func isTmpFileUnsupported(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EISDIR)
+}
+
+// linkAtomicTemp links the unnamed file f into dir, using a name generated
+// from pattern, as CreateTemp does, and returns the name.
+func linkAtomicTemp(f *os.File, dir, pattern string) (string, error) {
+	prefix, suffix, err := golang.PrefixAndSuffix(pattern)
+	if err != nil {
+		return "", createTempError(pattern, err)
+	}
+
+	oldname := procSelfFD + "/" + strconv.Itoa(int(f.Fd())) //nolint:gosec // fd fits in an int
+
+	try := 0
+
+	for {
+		name := filepath.Join(dir, prefix+golang.NextRandom()+suffix)
+
+		err = unix.Linkat(unix.AT_FDCWD, oldname, unix.AT_FDCWD, name, unix.AT_SYMLINK_FOLLOW)
+		if errors.Is(err, unix.EEXIST) {
+			if try++; try < 10000 { //nolint:mnd // as os.CreateTemp
+				continue
+			}
+		}
+
+		if err != nil {
+			return "", &os.LinkError{Op: "linkat", Old: oldname, New: name, Err: err}
+		}
+
+		return name, nil
+	}
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/rasa/compat"
+)
+
+func supportsTmpFile(t *testing.T, dir string) bool {
+	t.Helper()
+
+	f, err := compat.OpenTmpFileOS(dir, perm600, 0)
+	if err != nil {
+		t.Skipf("Skipping test: O_TMPFILE is not supported: %v", err)
+
+		return false
+	}
+
+	_ = f.Close()
+
+	return true
+}
+
+func readDirNames(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	return names
+}
+
+func writeAndCommit(t *testing.T, w *compat.AtomicWriter) {
+	t.Helper()
+
+	_, err := w.Write(helloBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(w.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("got %q, want %q", got, helloBytes)
+	}
+}
+
+func TestAtomicWriterTmpFile(t *testing.T) {
+	dir := tempDir(t)
+	if !supportsTmpFile(t, dir) {
+		return
+	}
+
+	file := filepath.Join(dir, "file.txt")
+
+	err := os.WriteFile(file, []byte("old"), perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := compat.NewAtomicWriter(file, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// the unnamed file isn't visible before Commit.
+	names := readDirNames(t, dir)
+	if len(names) != 1 || names[0] != "file.txt" {
+		t.Fatalf("got %q, want [\"file.txt\"]", names)
+	}
+
+	writeAndCommit(t, w)
+
+	names = readDirNames(t, dir)
+	if len(names) != 1 || names[0] != "file.txt" {
+		t.Fatalf("got %q, want [\"file.txt\"]", names)
+	}
+
+	fi, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.Mode().Perm() != perm600 {
+		t.Fatalf("got %04o, want %04o", fi.Mode().Perm(), perm600)
+	}
+}
+
+func TestAtomicWriterTmpFileAbort(t *testing.T) {
+	dir := tempDir(t)
+	if !supportsTmpFile(t, dir) {
+		return
+	}
+
+	w, err := compat.NewAtomicWriter(filepath.Join(dir, "file.txt"), perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = w.Write(helloBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Abort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := readDirNames(t, dir)
+	if len(names) != 0 {
+		t.Fatalf("got %q, want []", names)
+	}
+}
+
+func TestAtomicWriterTmpFileFallback(t *testing.T) {
+	restore := compat.SetOpenTmpFile(func(dir string, _ os.FileMode, _ int) (*os.File, error) {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: syscall.EOPNOTSUPP}
+	})
+	defer restore()
+
+	dir := tempDir(t)
+	file := filepath.Join(dir, "file.txt")
+
+	w, err := compat.NewAtomicWriter(file, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// the named temporary file is visible before Commit.
+	names := readDirNames(t, dir)
+	if len(names) != 1 || !strings.HasPrefix(names[0], "~") || !strings.HasSuffix(names[0], ".tmp") {
+		t.Fatalf("got %q, want a single ~*.tmp file", names)
+	}
+
+	writeAndCommit(t, w)
+
+	names = readDirNames(t, dir)
+	if len(names) != 1 || names[0] != "file.txt" {
+		t.Fatalf("got %q, want [\"file.txt\"]", names)
+	}
+}
+
+func TestAtomicWriterTmpFileError(t *testing.T) {
+	restore := compat.SetOpenTmpFile(func(dir string, _ os.FileMode, _ int) (*os.File, error) {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: syscall.EACCES}
+	})
+	defer restore()
+
+	_, err := compat.NewAtomicWriter(tempName(t), perm600)
+	if !errors.Is(err, syscall.EACCES) {
+		t.Fatalf("got %v, want %v", err, syscall.EACCES)
+	}
+}
+
+func TestAtomicWriterTmpFileFlags(t *testing.T) {
+	dir := tempDir(t)
+	if !supportsTmpFile(t, dir) {
+		return
+	}
+
+	f, err := compat.OpenTmpFileOS(dir, perm600, os.O_WRONLY|os.O_APPEND|os.O_SYNC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	flags, err := unix.FcntlInt(f.Fd(), unix.F_GETFL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if flags&unix.O_ACCMODE != unix.O_RDWR || flags&unix.O_APPEND == 0 || flags&unix.O_SYNC != unix.O_SYNC {
+		t.Fatalf("got flags %#o, want O_RDWR|O_APPEND|O_SYNC", flags)
+	}
+}
+
+func TestAtomicWriterTmpFileUnsupportedFlags(t *testing.T) {
+	dir := tempDir(t)
+	if !supportsTmpFile(t, dir) {
+		return
+	}
+
+	w, err := compat.NewAtomicWriter(filepath.Join(dir, "file.txt"), perm600,
+		compat.WithFlags(os.O_CREATE|os.O_WRONLY|os.O_TRUNC|unix.O_DIRECTORY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// an unnamed file can't honour O_DIRECTORY, so a named one is used.
+	names := readDirNames(t, dir)
+	if len(names) != 1 || !strings.HasPrefix(names[0], "~") {
+		t.Fatalf("got %q, want a single ~*.tmp file", names)
+	}
+}
+
+func TestAtomicWriterTmpFileTempPattern(t *testing.T) {
+	dir := tempDir(t)
+	if !supportsTmpFile(t, dir) {
+		return
+	}
+
+	sequentialRandom(t)
+
+	// a file with the first name forces the link to use the second one.
+	createAgedFile(t, dir, ".x-1.part", 0)
+
+	w, err := compat.NewAtomicWriter(filepath.Join(dir, "file.txt"), perm600, compat.WithTempPattern(".x-*.part"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	writeAndCommit(t, w)
+
+	names := readDirNames(t, dir)
+	if !slices.Equal(names, []string{".x-1.part", "file.txt"}) {
+		t.Fatalf("got %q, want [\".x-1.part\" \"file.txt\"]", names)
+	}
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !linux || android
+
+package compat
+
+import (
+	"os"
+)
+
+// createAtomicTemp creates a named temporary file in dir.
+func createAtomicTemp(dir, pattern string, perm os.FileMode, flag int) (*os.File, bool, error) {
+	f, err := createTemp(dir, pattern, perm, flag)
+
+	return f, false, err
+}
+
+func linkAtomicTemp(_ *os.File, _, _ string) (string, error) {
+	return "", &UnsupportedError{Op: "linkat"}
+}
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func atomicWriterOpts(opts ...compat.Option) []compat.Option {
+	if compat.IsPlan9 {
+		opts = append(opts, compat.WithNonAtomicReplace(true))
+	}
+
+	return opts
+}
+
+// assertOnlyFile fails if dir contains anything but the file named base.
+func assertOnlyFile(t *testing.T, dir, base string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Name() != base {
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+
+		t.Fatalf("got %q, want [%q]", names, base)
+	}
+}
+
+func TestAtomicWriterCommit(t *testing.T) {
+	dir := tempDir(t)
+	file := filepath.Join(dir, "file.json")
+
+	err := os.WriteFile(file, helloBytes, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := compat.NewAtomicWriter(file, perm600, atomicWriterOpts()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if w.Name() != file {
+		t.Fatalf("got %q, want %q", w.Name(), file)
+	}
+
+	want := map[string]string{"hello": "world"}
+
+	err = json.NewEncoder(w).Encode(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the destination is unchanged until Commit is called.
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("got %q, want %q", got, helloBytes)
+	}
+
+	err = w.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]string
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded["hello"] != "world" {
+		t.Fatalf("got %v, want %v", decoded, want)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("Close after Commit: got %v, want nil", err)
+	}
+
+	assertOnlyFile(t, dir, "file.json")
+}
+
+func TestAtomicWriterCloseDiscards(t *testing.T) {
+	dir := tempDir(t)
+	file := filepath.Join(dir, "file.txt")
+
+	err := os.WriteFile(file, helloBytes, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := compat.NewAtomicWriter(file, perm600, atomicWriterOpts()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = w.Write([]byte("discarded"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("got %q, want %q", got, helloBytes)
+	}
+
+	assertOnlyFile(t, dir, "file.txt")
+}
+
+func TestAtomicWriterAbort(t *testing.T) {
+	dir := tempDir(t)
+	file := filepath.Join(dir, "file.txt")
+
+	w, err := compat.NewAtomicWriter(file, perm600, atomicWriterOpts()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Abort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Abort()
+	if err != nil {
+		t.Fatalf("second Abort: got %v, want nil", err)
+	}
+
+	_, err = w.Write(helloBytes)
+	if !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Write: got %v, want %v", err, os.ErrClosed)
+	}
+
+	err = w.Commit()
+	if !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Commit: got %v, want %v", err, os.ErrClosed)
+	}
+
+	_, err = os.Stat(file)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("got %d entries, want 0 (the temp file was not removed)", len(entries))
+	}
+}
+
+func TestAtomicWriterKeepFileMode(t *testing.T) {
+	file := tempName(t)
+
+	cleanup(t, file)
+
+	perm := perm555
+
+	err := compat.WriteFile(file, helloBytes, perm)
+	if err != nil {
+		t.Fatalf("Failed to create file: %q: %v", file, err)
+	}
+
+	w, err := compat.NewAtomicWriter(file, 0, atomicWriterOpts(compat.WithKeepFileMode(true))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	_, err = w.Write(helloBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(file)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %q: %v", file, err)
+	}
+
+	want := fixPerms(perm, false)
+
+	got := fi.Mode().Perm()
+	if got != want {
+		t.Fatalf("got %04o, want %04o", got, want)
+	}
+}
+
+func TestAtomicWriterContextCancelled(t *testing.T) {
+	dir := tempDir(t)
+	file := filepath.Join(dir, "file.txt")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	w, err := compat.NewAtomicWriter(file, perm600, atomicWriterOpts(compat.WithContext(ctx))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	_, err = w.Write(helloBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	_, err = w.Write(helloBytes)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Write: got %v, want %v", err, context.Canceled)
+	}
+
+	err = w.Commit()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Commit: got %v, want %v", err, context.Canceled)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("got %d entries, want 0 (the temp file was not removed)", len(entries))
+	}
+}
+
+func TestAtomicWriterInvalid(t *testing.T) {
+	_, err := compat.NewAtomicWriter(invalidName, perm600)
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"strings"
+)
+
+// Attribute is a set of per-file attributes reported by the kernel, and is
+// returned by FileInfo's Attributes() function.
+//
+// On Linux, the attributes are sourced from statx(2)'s stx_attributes field,
+// and only include the attributes the filesystem reports as supported.
+// On Windows, only AttributeCompressed and AttributeEncrypted are reported.
+// On other operating systems, no attributes are reported.
+type Attribute uint64
+
+const (
+	// AttributeImmutable is set if the file cannot be modified, deleted,
+	// or renamed, and no link can be created to it.
+	AttributeImmutable Attribute = 1 << iota
+	// AttributeAppend is set if the file can only be opened in append mode
+	// for writing.
+	AttributeAppend
+	// AttributeCompressed is set if the file is compressed by the filesystem.
+	AttributeCompressed
+	// AttributeEncrypted is set if the file is encrypted by the filesystem.
+	AttributeEncrypted
+	// AttributeNoDump is set if the file is not a candidate for backup by
+	// dump(8).
+	AttributeNoDump
+	// AttributeDAX is set if the file is in the DAX (cpu direct access) state.
+	AttributeDAX
+	// AttributeVerity is set if the file has fs-verity enabled.
+	AttributeVerity
+)
+
+var attributeNames = []struct {
+	attr Attribute
+	name string
+}{
+	{AttributeImmutable, "immutable"},
+	{AttributeAppend, "append"},
+	{AttributeCompressed, "compressed"},
+	{AttributeEncrypted, "encrypted"},
+	{AttributeNoDump, "nodump"},
+	{AttributeDAX, "dax"},
+	{AttributeVerity, "verity"},
+}
+
+// Has returns true if all the attributes in attr are set.
+func (a Attribute) Has(attr Attribute) bool {
+	return a&attr == attr
+}
+
+// String returns the attribute names, separated by a pipe (|) character.
+func (a Attribute) String() string {
+	names := make([]string, 0, len(attributeNames))
+
+	for _, an := range attributeNames {
+		if a&an.attr != 0 {
+			names = append(names, an.name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
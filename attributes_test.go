@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestAttributeString(t *testing.T) {
+	tests := []struct {
+		attr compat.Attribute
+		want string
+	}{
+		{0, ""},
+		{compat.AttributeImmutable, "immutable"},
+		{compat.AttributeAppend | compat.AttributeNoDump, "append|nodump"},
+		{
+			compat.AttributeImmutable | compat.AttributeAppend | compat.AttributeCompressed |
+				compat.AttributeEncrypted | compat.AttributeNoDump | compat.AttributeDAX | compat.AttributeVerity,
+			"immutable|append|compressed|encrypted|nodump|dax|verity",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.attr.String(); got != tt.want {
+			t.Errorf("String(): got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAttributeHas(t *testing.T) {
+	attr := compat.AttributeImmutable | compat.AttributeNoDump
+
+	if !attr.Has(compat.AttributeImmutable) {
+		t.Errorf("Has(%v): got false, want true", compat.AttributeImmutable)
+	}
+
+	if !attr.Has(compat.AttributeImmutable | compat.AttributeNoDump) {
+		t.Errorf("Has(%v): got false, want true", attr)
+	}
+
+	if attr.Has(compat.AttributeImmutable | compat.AttributeAppend) {
+		t.Errorf("Has(%v): got true, want false", compat.AttributeImmutable|compat.AttributeAppend)
+	}
+}
+
+func TestAttributesNewFile(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	got := fi.Attributes()
+	if got.Has(compat.AttributeImmutable) || got.Has(compat.AttributeAppend) {
+		t.Errorf("Attributes(): got %v, want neither immutable nor append", got)
+	}
+}
@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Capability is a Linux capability, such as CapNetBindService.
+// See https://man7.org/linux/man-pages/man7/capabilities.7.html
+type Capability uint8
+
+// The Linux capabilities.
+const (
+	CapChown Capability = iota
+	CapDacOverride
+	CapDacReadSearch
+	CapFowner
+	CapFsetid
+	CapKill
+	CapSetgid
+	CapSetuid
+	CapSetpcap
+	CapLinuxImmutable
+	CapNetBindService
+	CapNetBroadcast
+	CapNetAdmin
+	CapNetRaw
+	CapIpcLock
+	CapIpcOwner
+	CapSysModule
+	CapSysRawio
+	CapSysChroot
+	CapSysPtrace
+	CapSysPacct
+	CapSysAdmin
+	CapSysBoot
+	CapSysNice
+	CapSysResource
+	CapSysTime
+	CapSysTtyConfig
+	CapMknod
+	CapLease
+	CapAuditWrite
+	CapAuditControl
+	CapSetfcap
+	CapMacOverride
+	CapMacAdmin
+	CapSyslog
+	CapWakeAlarm
+	CapBlockSuspend
+	CapAuditRead
+	CapPerfmon
+	CapBpf
+	CapCheckpointRestore
+)
+
+var capabilityNames = [...]string{
+	"cap_chown",
+	"cap_dac_override",
+	"cap_dac_read_search",
+	"cap_fowner",
+	"cap_fsetid",
+	"cap_kill",
+	"cap_setgid",
+	"cap_setuid",
+	"cap_setpcap",
+	"cap_linux_immutable",
+	"cap_net_bind_service",
+	"cap_net_broadcast",
+	"cap_net_admin",
+	"cap_net_raw",
+	"cap_ipc_lock",
+	"cap_ipc_owner",
+	"cap_sys_module",
+	"cap_sys_rawio",
+	"cap_sys_chroot",
+	"cap_sys_ptrace",
+	"cap_sys_pacct",
+	"cap_sys_admin",
+	"cap_sys_boot",
+	"cap_sys_nice",
+	"cap_sys_resource",
+	"cap_sys_time",
+	"cap_sys_tty_config",
+	"cap_mknod",
+	"cap_lease",
+	"cap_audit_write",
+	"cap_audit_control",
+	"cap_setfcap",
+	"cap_mac_override",
+	"cap_mac_admin",
+	"cap_syslog",
+	"cap_wake_alarm",
+	"cap_block_suspend",
+	"cap_audit_read",
+	"cap_perfmon",
+	"cap_bpf",
+	"cap_checkpoint_restore",
+}
+
+// String returns the capability's name, as used by getcap(8), such as
+// "cap_net_bind_service".
+func (c Capability) String() string {
+	if int(c) < len(capabilityNames) {
+		return capabilityNames[c]
+	}
+
+	return strconv.Itoa(int(c))
+}
+
+// A CapSet is a set of capabilities.
+type CapSet uint64
+
+// NewCapSet returns a CapSet holding caps.
+func NewCapSet(caps ...Capability) CapSet {
+	var s CapSet
+
+	for _, c := range caps {
+		s |= 1 << c
+	}
+
+	return s
+}
+
+// Has returns true if c is in the set.
+func (s CapSet) Has(c Capability) bool {
+	return c < 64 && s&(1<<c) != 0 //nolint:mnd
+}
+
+// Capabilities returns the capabilities in the set, in ascending order.
+func (s CapSet) Capabilities() []Capability {
+	caps := make([]Capability, 0)
+
+	for c := range Capability(64) { //nolint:mnd
+		if s.Has(c) {
+			caps = append(caps, c)
+		}
+	}
+
+	return caps
+}
+
+// FileCaps are the capabilities of an executable file, stored in its
+// security.capability extended attribute.
+type FileCaps struct {
+	Permitted   CapSet // capabilities permitted to the process
+	Inheritable CapSet // capabilities inherited from the parent process
+	Effective   bool   // if true, the permitted capabilities are raised when the file is executed
+	RootID      uint32 // the user namespace's root user ID, or 0 for the initial user namespace
+}
+
+const (
+	capXattr = "security.capability"
+
+	vfsCapRevisionMask  = 0xff000000
+	vfsCapRevision1     = 0x01000000
+	vfsCapRevision2     = 0x02000000
+	vfsCapRevision3     = 0x03000000
+	vfsCapFlagEffective = 0x000001
+
+	vfsCapSize1 = 4 + 1*8
+	vfsCapSize2 = 4 + 2*8
+	vfsCapSize3 = vfsCapSize2 + 4
+)
+
+// clone returns a copy of c, so callers can't modify a cached value.
+func (c *FileCaps) clone() *FileCaps {
+	if c == nil {
+		return nil
+	}
+
+	caps := *c
+
+	return &caps
+}
+
+// ErrInvalidCaps is returned if the security.capability extended attribute
+// can't be decoded.
+var ErrInvalidCaps = errors.New("invalid file capabilities")
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. It returns caps in
+// the format of the security.capability extended attribute, using
+// revision 3 if RootID is set, otherwise revision 2.
+func (caps FileCaps) MarshalBinary() ([]byte, error) {
+	magic := uint32(vfsCapRevision2)
+	size := vfsCapSize2
+
+	if caps.RootID != 0 {
+		magic = vfsCapRevision3
+		size = vfsCapSize3
+	}
+
+	if caps.Effective {
+		magic |= vfsCapFlagEffective
+	}
+
+	data := make([]byte, 0, size)
+	data = binary.LittleEndian.AppendUint32(data, magic)
+	data = binary.LittleEndian.AppendUint32(data, uint32(caps.Permitted))
+	data = binary.LittleEndian.AppendUint32(data, uint32(caps.Inheritable))
+	data = binary.LittleEndian.AppendUint32(data, uint32(caps.Permitted>>32))   //nolint:mnd
+	data = binary.LittleEndian.AppendUint32(data, uint32(caps.Inheritable>>32)) //nolint:mnd
+
+	if caps.RootID != 0 {
+		data = binary.LittleEndian.AppendUint32(data, caps.RootID)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler]. It decodes
+// revision 1, 2, and 3 security.capability extended attributes.
+func (caps *FileCaps) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 { //nolint:mnd
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidCaps, len(data))
+	}
+
+	magic := binary.LittleEndian.Uint32(data)
+
+	var want int
+
+	switch magic & vfsCapRevisionMask {
+	case vfsCapRevision1:
+		want = vfsCapSize1
+	case vfsCapRevision2:
+		want = vfsCapSize2
+	case vfsCapRevision3:
+		want = vfsCapSize3
+	default:
+		return fmt.Errorf("%w: unsupported revision 0x%08x", ErrInvalidCaps, magic&vfsCapRevisionMask)
+	}
+
+	if len(data) != want {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidCaps, len(data))
+	}
+
+	*caps = FileCaps{
+		Permitted:   CapSet(binary.LittleEndian.Uint32(data[4:])),
+		Inheritable: CapSet(binary.LittleEndian.Uint32(data[8:])),
+		Effective:   magic&vfsCapFlagEffective != 0,
+	}
+
+	if len(data) >= vfsCapSize2 {
+		caps.Permitted |= CapSet(binary.LittleEndian.Uint32(data[12:])) << 32   //nolint:mnd
+		caps.Inheritable |= CapSet(binary.LittleEndian.Uint32(data[16:])) << 32 //nolint:mnd
+	}
+
+	if len(data) == vfsCapSize3 {
+		caps.RootID = binary.LittleEndian.Uint32(data[20:])
+	}
+
+	return nil
+}
+
+// String returns caps in the text form used by getcap(8), such as
+// "cap_net_admin,cap_net_raw=ep cap_net_bind_service=eip".
+func (caps FileCaps) String() string {
+	var groups []string
+
+	flags := map[string][]string{}
+
+	for _, c := range (caps.Permitted | caps.Inheritable).Capabilities() {
+		f := ""
+		if caps.Effective {
+			f += "e"
+		}
+
+		if caps.Inheritable.Has(c) {
+			f += "i"
+		}
+
+		if caps.Permitted.Has(c) {
+			f += "p"
+		}
+
+		if _, ok := flags[f]; !ok {
+			groups = append(groups, f)
+		}
+
+		flags[f] = append(flags[f], c.String())
+	}
+
+	parts := make([]string, 0, len(groups))
+	for _, f := range groups {
+		parts = append(parts, strings.Join(flags[f], ",")+"="+f)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// GetCapabilities returns the capabilities of the named file, or nil if it
+// has none.
+// File capabilities are supported on Linux only. On other operating systems,
+// the error wraps an *UnsupportedError.
+// If there is an error, it will be of type [*PathError].
+func GetCapabilities(name string) (*FileCaps, error) {
+	return getCapabilities(name, true)
+}
+
+// SetCapabilities sets the capabilities of the named file. This requires
+// the CAP_SETFCAP capability.
+// If there is an error, it will be of type [*PathError].
+func SetCapabilities(name string, caps FileCaps) error {
+	return setCapabilities(name, caps)
+}
+
+// RemoveCapabilities removes the capabilities of the named file. It is not
+// an error if the file has none.
+// If there is an error, it will be of type [*PathError].
+func RemoveCapabilities(name string) error {
+	return removeCapabilities(name)
+}
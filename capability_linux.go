@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package compat
+
+import (
+	"errors"
+	"os"
+)
+
+// Capabilities returns a copy of the file's capabilities, or nil if it has
+// none. The capabilities are looked up the first time the function is called,
+// unless the FileInfo was returned by Fstat, which reads them from the file.
+func (fs *fileStat) Capabilities() *FileCaps {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	if !fs.capsed {
+		fs.capsed = true
+
+		caps, err := getCapabilities(fs.path, fs.followSymlinks)
+		if err != nil {
+			fs.setError(FieldCaps, "getxattr", err)
+
+			return nil
+		}
+
+		fs.caps = caps
+	}
+
+	return fs.caps.clone()
+}
+
+// fstatCapabilities reads the capabilities of the open file f into info, so
+// they describe the file, and not whatever its name now refers to.
+func fstatCapabilities(info FileInfo, f *os.File) FileInfo {
+	fs, ok := info.(*fileStat)
+	if !ok {
+		return info
+	}
+
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	fs.capsed = true
+
+	data, err := fgetXattr(f, capXattr)
+
+	caps, err := decodeCapabilities(f.Name(), data, err)
+	if err != nil {
+		fs.setError(FieldCaps, "fgetxattr", err)
+
+		return info
+	}
+
+	fs.caps = caps
+
+	return info
+}
+
+func getCapabilities(name string, followSymlinks bool) (*FileCaps, error) {
+	data, err := getXattr(name, capXattr, followSymlinks)
+
+	return decodeCapabilities(name, data, err)
+}
+
+// decodeCapabilities decodes the security.capability extended attribute
+// data, returned with err, by a get function.
+func decodeCapabilities(name string, data []byte, err error) (*FileCaps, error) {
+	if errors.Is(err, ErrNoXattr) || IsUnsupportedError(err) {
+		return nil, nil //nolint:nilnil // no capabilities
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var caps FileCaps
+
+	err = caps.UnmarshalBinary(data)
+	if err != nil {
+		return nil, &os.PathError{Op: "getcap", Path: name, Err: err}
+	}
+
+	return &caps, nil
+}
+
+func setCapabilities(name string, caps FileCaps) error {
+	data, err := caps.MarshalBinary()
+	if err != nil {
+		return &os.PathError{Op: "setcap", Path: name, Err: err}
+	}
+
+	return SetXattr(name, capXattr, data, 0)
+}
+
+func removeCapabilities(name string) error {
+	err := RemoveXattr(name, capXattr)
+	if errors.Is(err, ErrNoXattr) {
+		return nil
+	}
+
+	return err
+}
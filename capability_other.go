@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+
+package compat
+
+import (
+	"os"
+)
+
+// Capabilities returns nil, as file capabilities are supported on Linux only.
+func (fs *fileStat) Capabilities() *FileCaps { return nil }
+
+func getCapabilities(name string, _ bool) (*FileCaps, error) {
+	return nil, capabilitiesError("getcap", name)
+}
+
+func setCapabilities(name string, _ FileCaps) error {
+	return capabilitiesError("setcap", name)
+}
+
+func removeCapabilities(name string) error {
+	return capabilitiesError("setcap", name)
+}
+
+func capabilitiesError(op, path string) error {
+	return &os.PathError{Op: op, Path: path, Err: &UnsupportedError{Op: op}}
+}
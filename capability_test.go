@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestFileCapsMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		caps compat.FileCaps
+		data []byte
+	}{
+		{
+			"v2",
+			compat.FileCaps{Permitted: compat.NewCapSet(compat.CapNetBindService), Effective: true},
+			[]byte{1, 0, 0, 2, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			"v3",
+			compat.FileCaps{
+				Permitted:   compat.NewCapSet(compat.CapNetRaw, compat.CapBpf),
+				Inheritable: compat.NewCapSet(compat.CapChown),
+				RootID:      100000,
+			},
+			[]byte{0, 0, 0, 3, 0, 0x20, 0, 0, 1, 0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0, 0, 0xa0, 0x86, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.caps.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(data, tt.data) {
+				t.Fatalf("MarshalBinary():\ngot  %v\nwant %v", data, tt.data)
+			}
+
+			var caps compat.FileCaps
+
+			err = caps.UnmarshalBinary(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if caps != tt.caps {
+				t.Fatalf("UnmarshalBinary(): got %+v, want %+v", caps, tt.caps)
+			}
+		})
+	}
+}
+
+func TestFileCapsUnmarshalBinary(t *testing.T) {
+	var caps compat.FileCaps
+
+	// Revision 1 only has 32-bit sets.
+	err := caps.UnmarshalBinary([]byte{1, 0, 0, 1, 0, 4, 0, 0, 0, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := compat.FileCaps{Permitted: compat.NewCapSet(compat.CapNetBindService), Effective: true}
+	if caps != want {
+		t.Fatalf("UnmarshalBinary(): got %+v, want %+v", caps, want)
+	}
+
+	for _, data := range [][]byte{nil, {0, 0, 0, 2}, {0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0}} {
+		err = caps.UnmarshalBinary(data)
+		if !errors.Is(err, compat.ErrInvalidCaps) {
+			t.Fatalf("UnmarshalBinary(%v): got %v, want %v", data, err, compat.ErrInvalidCaps)
+		}
+	}
+}
+
+func TestFileCapsString(t *testing.T) {
+	caps := compat.FileCaps{
+		Permitted:   compat.NewCapSet(compat.CapNetAdmin, compat.CapNetRaw, compat.CapNetBindService),
+		Inheritable: compat.NewCapSet(compat.CapNetBindService),
+		Effective:   true,
+	}
+
+	if got, want := caps.String(), "cap_net_bind_service=eip cap_net_admin,cap_net_raw=ep"; got != want {
+		t.Fatalf("String(): got %q, want %q", got, want)
+	}
+
+	if got, want := compat.Capability(63).String(), "63"; got != want {
+		t.Fatalf("String(): got %q, want %q", got, want)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := compat.GetCapabilities(name)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: file capabilities not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if caps != nil {
+		t.Fatalf("GetCapabilities(): got %v, want nil", caps)
+	}
+
+	want := compat.FileCaps{Permitted: compat.NewCapSet(compat.CapNetBindService), Effective: true}
+
+	err = compat.SetCapabilities(name, want)
+	if errors.Is(err, os.ErrPermission) || compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: setting file capabilities requires CAP_SETFCAP: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err = compat.GetCapabilities(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if caps == nil || *caps != want {
+		t.Fatalf("GetCapabilities(): got %v, want %v", caps, want)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.Capabilities(); got == nil || *got != want {
+		t.Fatalf("Capabilities(): got %v, want %v", got, want)
+	} else {
+		// the result is a copy, so changing it doesn't change the FileInfo.
+		got.Effective = false
+	}
+
+	if got := fi.Capabilities(); got == nil || *got != want {
+		t.Fatalf("Capabilities(): got %v, want %v, after changing a previous result", got, want)
+	}
+
+	testFstatCapabilities(t, name, want)
+
+	data, err := json.Marshal(compat.NewSnapshot(fi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := snapshotFromJSON(t, string(data))
+	if got := s.Capabilities(); got == nil || *got != want {
+		t.Fatalf("Snapshot Capabilities(): got %v, want %v", got, want)
+	}
+
+	err = compat.RemoveCapabilities(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.RemoveCapabilities(name)
+	if err != nil {
+		t.Fatalf("RemoveCapabilities(): got %v, want nil", err)
+	}
+
+	caps, err = compat.GetCapabilities(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if caps != nil {
+		t.Fatalf("GetCapabilities(): got %v, want nil", caps)
+	}
+}
+
+func TestCapabilitiesUnsupported(t *testing.T) {
+	if runtime.GOOS == "linux" || runtime.GOOS == "android" {
+		skip(t, "Skipping test: file capabilities are supported on "+runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.GetCapabilities(name)
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("GetCapabilities(): got %v, want an UnsupportedError", err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.Capabilities(); got != nil {
+		t.Fatalf("Capabilities(): got %v, want nil", got)
+	}
+}
+
+// testFstatCapabilities checks that Fstat reads the capabilities from the
+// open file, and not from whatever file its name refers to later.
+func testFstatCapabilities(t *testing.T, name string, want compat.FileCaps) {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	fi, err := compat.Fstat(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replace the file with one without capabilities.
+	other, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved := name + ".saved"
+
+	err = os.Rename(name, saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Rename(other, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.Capabilities(); got == nil || *got != want {
+		t.Fatalf("Fstat Capabilities(): got %v, want %v", got, want)
+	}
+
+	err = os.Rename(saved, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
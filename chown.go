@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+// Chown changes the numeric user and group IDs of the named file. An ID of
+// UnknownID (-1) is left unchanged. If the file is a symbolic link, it
+// changes the owner of the link's target.
+//
+// The IDs are the values returned by FileInfo's UID() and GID() functions,
+// so they round-trip on every OS. On Windows, the IDs are mapped back to
+// SIDs by [github.com/rasa/compat/sid.Mapper.FromPOSIXID], which reverses the
+// mapping that UID() and GID() use. IDs from 0x30000 to 0x3ffff are mapped to
+// accounts in the current user's domain, and 0x30201 is always Everyone.
+// On Plan 9, the IDs are hashes of the user and group names, so Chown
+// returns an *UnsupportedError. Use ChownNames instead.
+// If there is an error, it will be of type [*PathError].
+func Chown(name string, uid, gid int, opts ...Option) error {
+	return chown(name, uid, gid, true, opts...)
+}
+
+// Lchown is like Chown, but if the file is a symbolic link, it changes the
+// owner of the link itself.
+// If there is an error, it will be of type [*PathError].
+func Lchown(name string, uid, gid int, opts ...Option) error {
+	return chown(name, uid, gid, false, opts...)
+}
+
+// ChownNames changes the user and group of the named file, by name. An empty
+// name is left unchanged. If the file is a symbolic link, it changes the owner
+// of the link's target.
+//
+// The names are the values returned by FileInfo's User() and Group()
+// functions, so they round-trip on every OS. On Windows, a name may include a
+// domain, such as `BUILTIN\Administrators`.
+// If there is an error, it will be of type [*PathError].
+func ChownNames(name, user, group string, opts ...Option) error {
+	return chownNames(name, user, group, opts...)
+}
+
+// resolveIDs maps the user and group names to IDs with r. An empty name is
+// mapped to UnknownID.
+func resolveIDs(r Resolver, username, groupname string) (int, int, error) {
+	uid := UnknownID
+	gid := UnknownID
+
+	var err error
+
+	if username != "" {
+		uid, err = r.UserID(username)
+		if err != nil {
+			return UnknownID, UnknownID, err
+		}
+	}
+
+	if groupname != "" {
+		gid, err = r.GroupID(groupname)
+		if err != nil {
+			return UnknownID, UnknownID, err
+		}
+	}
+
+	return uid, gid, nil
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package compat
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func chown(name string, uid, gid int, followSymlinks bool, _ ...Option) error {
+	flags := 0
+	if !followSymlinks {
+		flags = unix.AT_SYMLINK_NOFOLLOW
+	}
+
+	err := unix.Fchownat(unix.AT_FDCWD, name, uid, gid, flags)
+	if err != nil {
+		if !followSymlinks {
+			return lchownError(name, err)
+		}
+
+		return chownError(name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(linux || plan9 || windows)
+
+package compat
+
+import (
+	"os"
+)
+
+func chown(name string, uid, gid int, followSymlinks bool, _ ...Option) error {
+	if followSymlinks {
+		return os.Chown(name, uid, gid)
+	}
+
+	return os.Lchown(name, uid, gid)
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build plan9
+
+package compat
+
+import (
+	"syscall"
+)
+
+func chown(name string, uid, gid int, followSymlinks bool, _ ...Option) error {
+	if uid == UnknownID && gid == UnknownID {
+		return nil
+	}
+
+	err := &UnsupportedError{Op: "chown"}
+	if !followSymlinks {
+		return lchownError(name, err)
+	}
+
+	return chownError(name, err)
+}
+
+// chownNames sets the Uid and Gid fields with wstat(5). Plan 9 file
+// servers usually only permit the group to be changed.
+// See https://github.com/golang/go/blob/d13da639/src/os/file_plan9.go#L421
+func chownNames(name, username, groupname string, _ ...Option) error {
+	if username == "" && groupname == "" {
+		return nil
+	}
+
+	var d syscall.Dir
+
+	d.Null()
+	d.Uid = username
+	d.Gid = groupname
+
+	buf := make([]byte, syscall.STATFIXLEN+len(username)+len(groupname))
+
+	n, err := d.Marshal(buf)
+	if err != nil {
+		return chownError(name, err)
+	}
+
+	err = syscall.Wstat(name, buf[:n])
+	if err != nil {
+		return chownError(name, err)
+	}
+
+	return nil
+}
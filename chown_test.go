@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+const (
+	chownUID = 1234
+	chownGID = 4321
+)
+
+func TestChownRoundTrip(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want.UID() == compat.UnknownID || want.GID() == compat.UnknownID {
+		skipf(t, "Skipping test: UID and GID not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	err = compat.Chown(name, want.UID(), want.GID())
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: Chown not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.UID() != want.UID() || got.GID() != want.GID() {
+		t.Fatalf("Chown(): got %d:%d, want %d:%d", got.UID(), got.GID(), want.UID(), want.GID())
+	}
+}
+
+func TestChownNamesRoundTrip(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want.User() == "" || want.Group() == "" {
+		skipf(t, "Skipping test: user and group names not supported on %v: %v", runtime.GOOS, want.Error())
+
+		return
+	}
+
+	err = compat.ChownNames(name, want.User(), want.Group())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.User() != want.User() || got.Group() != want.Group() {
+		t.Fatalf("ChownNames(): got %v:%v, want %v:%v", got.User(), got.Group(), want.User(), want.Group())
+	}
+}
+
+func TestChownUnchanged(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.Chown(name, compat.UnknownID, compat.UnknownID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.ChownNames(name, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChownRoot(t *testing.T) {
+	if !canChownToAnyID(t) {
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.Chown(name, chownUID, chownGID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.UID() != chownUID || fi.GID() != chownGID {
+		t.Fatalf("Chown(): got %d:%d, want %d:%d", fi.UID(), fi.GID(), chownUID, chownGID)
+	}
+}
+
+func TestLchownRoot(t *testing.T) {
+	if !supportsSymlinks(t) || !canChownToAnyID(t) {
+		return
+	}
+
+	target, link, err := createTempSymlink(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := compat.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.Lchown(link, chownUID, chownGID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.UID() != chownUID || fi.GID() != chownGID {
+		t.Fatalf("Lchown(): got %d:%d, want %d:%d", fi.UID(), fi.GID(), chownUID, chownGID)
+	}
+
+	got, err := compat.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.UID() != want.UID() || got.GID() != want.GID() {
+		t.Fatalf("Lchown(): target got %d:%d, want %d:%d", got.UID(), got.GID(), want.UID(), want.GID())
+	}
+}
+
+// canChownToAnyID returns true if files can be given to arbitrary IDs.
+func canChownToAnyID(t *testing.T) bool {
+	t.Helper()
+
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		skipf(t, "Skipping test: arbitrary IDs not supported on %v", runtime.GOOS)
+
+		return false
+	}
+
+	if os.Geteuid() != 0 {
+		skip(t, "Skipping test: requires root")
+
+		return false
+	}
+
+	return true
+}
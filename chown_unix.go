@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(plan9 || windows)
+
+package compat
+
+func chownNames(name, username, groupname string, opts ...Option) error {
+	uid, gid, err := resolveIDs(resolver(buildOptions(opts...)), username, groupname)
+	if err != nil {
+		return chownError(name, err)
+	}
+
+	return chown(name, uid, gid, true, opts...)
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package compat
+
+import (
+	"golang.org/x/sys/windows"
+
+	"github.com/rasa/compat/golang"
+)
+
+func chown(name string, uid, gid int, followSymlinks bool, _ ...Option) error {
+	err := chownIDs(name, uid, gid, followSymlinks)
+	if err != nil {
+		if !followSymlinks {
+			return lchownError(name, err)
+		}
+
+		return chownError(name, err)
+	}
+
+	return nil
+}
+
+func chownIDs(name string, uid, gid int, followSymlinks bool) error {
+	if uid == UnknownID && gid == UnknownID {
+		return nil
+	}
+
+	m, err := sidMapper()
+	if err != nil {
+		return err
+	}
+
+	var owner, group *windows.SID
+
+	if uid != UnknownID {
+		owner, err = posixIDToSID(uid, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	if gid != UnknownID {
+		group, err = posixIDToSID(gid, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return setOwnerSIDs(name, owner, group, followSymlinks)
+}
+
+func chownNames(name, username, groupname string, opts ...Option) error {
+	options := buildOptions(opts...)
+	if options.resolver != nil {
+		return chownResolved(name, username, groupname, options.resolver)
+	}
+
+	var owner, group *windows.SID
+
+	var err error
+
+	if username != "" {
+		owner, _, _, err = windows.LookupSID("", username)
+		if err != nil {
+			return chownError(name, err)
+		}
+	}
+
+	if groupname != "" {
+		group, _, _, err = windows.LookupSID("", groupname)
+		if err != nil {
+			return chownError(name, err)
+		}
+	}
+
+	err = setOwnerSIDs(name, owner, group, true)
+	if err != nil {
+		return chownError(name, err)
+	}
+
+	return nil
+}
+
+// chownResolved maps the names to IDs with r, such as the names read from a
+// tar archive, and maps the IDs to SIDs.
+func chownResolved(name, username, groupname string, r Resolver) error {
+	uid, gid, err := resolveIDs(r, username, groupname)
+	if err != nil {
+		return chownError(name, err)
+	}
+
+	return chown(name, uid, gid, true)
+}
+
+// setOwnerSIDs sets the owner and primary group SIDs of the named file. A nil
+// SID is left unchanged.
+func setOwnerSIDs(name string, owner, group *windows.SID, followSymlinks bool) error {
+	var info windows.SECURITY_INFORMATION
+
+	if owner != nil {
+		info |= windows.OWNER_SECURITY_INFORMATION
+	}
+
+	if group != nil {
+		info |= windows.GROUP_SECURITY_INFORMATION
+	}
+
+	if info == 0 {
+		return nil
+	}
+
+	path16, err := windows.UTF16PtrFromString(golang.FixLongPath(name))
+	if err != nil {
+		return err
+	}
+
+	var flags uint32 = windows.FILE_FLAG_BACKUP_SEMANTICS
+	if !followSymlinks {
+		flags |= windows.FILE_FLAG_OPEN_REPARSE_POINT
+	}
+
+	h, err := windows.CreateFile(
+		path16,
+		windows.WRITE_OWNER,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil,
+		windows.OPEN_EXISTING,
+		flags,
+		0,
+	)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	return windows.SetSecurityInfo(h, windows.SE_FILE_OBJECT, info, owner, group, nil, nil)
+}
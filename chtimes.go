@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"time"
+)
+
+// FileTimes are the times set by the Chtimes and Lchtimes functions.
+// A field left as the zero time.Time is left unchanged (UTIME_OMIT semantics).
+type FileTimes struct {
+	ATime time.Time // last accessed time
+	MTime time.Time // last modified time
+	BTime time.Time // created (birthed) time, see SupportsBTimeSetting
+}
+
+// Chtimes changes the access, modification, and birth times of the named
+// file, similar to the Unix utime() or utimes() functions. A zero time is
+// left unchanged. If the file is a symbolic link, it changes the times of the
+// link's target.
+//
+// If the OS cannot set one of the requested times, Chtimes returns an error
+// wrapping an *UnsupportedError, and no times are changed. On Unix, the access
+// and modification times are set before the birth time, so if setting the
+// birth time fails, the other times will have already been changed.
+// Birth times can be set on macOS, iOS and Windows only.
+// If there is an error, it will be of type [*PathError].
+func Chtimes(name string, times FileTimes, opts ...Option) error {
+	err := checkFileTimes(times)
+	if err != nil {
+		return chtimesError(name, err)
+	}
+
+	return chtimes(name, times, true, opts...)
+}
+
+// Lchtimes is like Chtimes, but if the file is a symbolic link, it changes
+// the times of the link itself.
+// If there is an error, it will be of type [*PathError].
+func Lchtimes(name string, times FileTimes, opts ...Option) error {
+	err := checkFileTimes(times)
+	if err != nil {
+		return lchtimesError(name, err)
+	}
+
+	return chtimes(name, times, false, opts...)
+}
+
+// SupportsBTimeSetting returns true if setting a file's birth time, via
+// Chtimes and Lchtimes, is supported by the OS.
+func SupportsBTimeSetting() bool {
+	return supportsBTimeSetting
+}
+
+func checkFileTimes(times FileTimes) error {
+	if !times.ATime.IsZero() && !SupportsATimeSetting() {
+		return &UnsupportedError{Op: "atime"}
+	}
+
+	if !times.BTime.IsZero() && !supportsBTimeSetting {
+		return &UnsupportedError{Op: "btime"}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin
+
+// The darwin build flag includes ios
+
+package compat
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const supportsBTimeSetting = true
+
+// See https://github.com/apple-oss-distributions/xnu/blob/e3723e1f/bsd/sys/stat.h#L573
+const utimeOmit = -2
+
+// setBTime sets the birth time via setattrlist(2).
+func setBTime(name string, btime time.Time, followSymlinks bool) error {
+	attrList := unix.Attrlist{
+		Bitmapcount: unix.ATTR_BIT_MAP_COUNT,
+		Commonattr:  unix.ATTR_CMN_CRTIME,
+	}
+
+	ts := unix.NsecToTimespec(btime.UnixNano())
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&ts)), unsafe.Sizeof(ts))
+
+	options := 0
+	if !followSymlinks {
+		options = unix.FSOPT_NOFOLLOW
+	}
+
+	return unix.Setattrlist(name, &attrList, buf, options)
+}
@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build netbsd
+
+package compat
+
+// See https://github.com/NetBSD/src/blob/8b3c5d3c/sys/sys/stat.h#L236
+const utimeOmit = (1 << 30) - 2 //nolint:mnd
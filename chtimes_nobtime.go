@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build unix && !darwin
+
+package compat
+
+import (
+	"time"
+)
+
+const supportsBTimeSetting = false
+
+func setBTime(_ string, _ time.Time, _ bool) error {
+	return &UnsupportedError{Op: "btime"}
+}
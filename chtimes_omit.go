@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build unix && !darwin && !netbsd
+
+package compat
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+const utimeOmit = unix.UTIME_OMIT
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(unix || windows)
+
+package compat
+
+import (
+	"os"
+)
+
+const supportsBTimeSetting = false
+
+func chtimes(name string, times FileTimes, followSymlinks bool, _ ...Option) error {
+	if !followSymlinks {
+		fi, err := os.Lstat(name)
+		if err != nil {
+			return lchtimesError(name, err)
+		}
+
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return lchtimesError(name, &UnsupportedError{Op: "lchtimes"})
+		}
+	}
+
+	// os.Chtimes leaves zero times unchanged.
+	return os.Chtimes(name, times.ATime, times.MTime)
+}
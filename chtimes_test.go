@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/rasa/compat"
+)
+
+func TestChtimes(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	times := compat.FileTimes{MTime: mtime}
+	if compat.SupportsATimeSetting() {
+		times.ATime = time.Now().Add(-72 * time.Hour).Truncate(time.Second)
+	}
+
+	err = compat.Chtimes(name, times)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.ModTime(); !compareTimes(got, mtime, testEnv.mtimeGranularity) {
+		fatalTimes(t, "ModTime()", got, mtime, testEnv.mtimeGranularity)
+	}
+
+	if times.ATime.IsZero() {
+		return
+	}
+
+	if got := fi.ATime(); !compareTimes(got, times.ATime, testEnv.atimeGranularity) {
+		fatalTimes(t, "ATime()", got, times.ATime, testEnv.atimeGranularity)
+	}
+}
+
+func TestChtimesOmit(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	err = compat.Chtimes(name, compat.FileTimes{MTime: mtime})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.Chtimes(name, compat.FileTimes{ATime: time.Time{}, MTime: time.Time{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.ModTime(); !compareTimes(got, mtime, testEnv.mtimeGranularity) {
+		fatalTimes(t, "ModTime()", got, mtime, testEnv.mtimeGranularity)
+	}
+}
+
+func TestChtimesBTime(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	btime := time.Now().Add(-96 * time.Hour).Truncate(time.Second)
+
+	err = compat.Chtimes(name, compat.FileTimes{BTime: btime})
+	if !compat.SupportsBTimeSetting() {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Fatalf("Chtimes(): got %v, want %v", err, errors.ErrUnsupported)
+		}
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !compat.SupportsBTime() {
+		return
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.BTime(); !compareTimes(got, btime, testEnv.btimeGranularity) {
+		fatalTimes(t, "BTime()", got, btime, testEnv.btimeGranularity)
+	}
+}
+
+func TestChtimesBTimeWithEarlierMTime(t *testing.T) {
+	if !compat.SupportsBTimeSetting() || !compat.SupportsBTime() {
+		skipf(t, "Skipping test: setting btime is not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	btime := time.Now().Add(-96 * time.Hour).Truncate(time.Second)
+	mtime := btime.Add(-24 * time.Hour)
+
+	err = compat.Chtimes(name, compat.FileTimes{MTime: mtime, BTime: btime})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.BTime(); !compareTimes(got, btime, testEnv.btimeGranularity) {
+		fatalTimes(t, "BTime()", got, btime, testEnv.btimeGranularity)
+	}
+
+	if got := fi.ModTime(); !compareTimes(got, mtime, testEnv.mtimeGranularity) {
+		fatalTimes(t, "ModTime()", got, mtime, testEnv.mtimeGranularity)
+	}
+}
+
+func TestChtimesUnsupportedLeavesTimes(t *testing.T) {
+	if compat.SupportsBTimeSetting() {
+		skipf(t, "Skipping test: setting btime is supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().Add(-48 * time.Hour)
+
+	err = compat.Chtimes(name, compat.FileTimes{MTime: mtime, BTime: mtime})
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("Chtimes(): got %v, want an UnsupportedError", err)
+	}
+
+	after, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := after.ModTime(), before.ModTime(); !got.Equal(want) {
+		t.Fatalf("ModTime(): got %v, want %v", got, want)
+	}
+}
+
+func TestLchtimes(t *testing.T) {
+	if !supportsSymlinks(t) {
+		skip(t, "Skipping test: Symlinks not supported on "+runtime.GOOS)
+
+		return
+	}
+
+	target, link, err := createTempSymlink(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := compat.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	err = compat.Lchtimes(link, compat.FileTimes{MTime: mtime})
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: Lchtimes() not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fi.ModTime(); !compareTimes(got, mtime, testEnv.mtimeGranularity) {
+		fatalTimes(t, "ModTime()", got, mtime, testEnv.mtimeGranularity)
+	}
+
+	after, err := compat.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := after.ModTime(), before.ModTime(); !got.Equal(want) {
+		t.Fatalf("target ModTime(): got %v, want %v", got, want)
+	}
+}
+
+func TestChtimesNotExist(t *testing.T) {
+	name := filepath.Join(tempDir(t), "does-not-exist")
+
+	err := compat.Chtimes(name, compat.FileTimes{MTime: time.Now()})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Chtimes(): got %v, want %v", err, fs.ErrNotExist)
+	}
+
+	err = compat.Lchtimes(name, compat.FileTimes{MTime: time.Now()})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Lchtimes(): got %v, want %v", err, fs.ErrNotExist)
+	}
+}
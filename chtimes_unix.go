@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package compat
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func chtimes(name string, times FileTimes, followSymlinks bool, _ ...Option) error {
+	errorFunc := chtimesError
+
+	flags := 0
+	if !followSymlinks {
+		errorFunc = lchtimesError
+		flags = unix.AT_SYMLINK_NOFOLLOW
+	}
+
+	if !times.ATime.IsZero() || !times.MTime.IsZero() {
+		ts := []unix.Timespec{utimeTimespec(times.ATime), utimeTimespec(times.MTime)}
+
+		err := unix.UtimesNanoAt(unix.AT_FDCWD, name, ts, flags)
+		if err != nil {
+			return errorFunc(name, err)
+		}
+	}
+
+	// Set the birth time last, as on macOS, setting a modification time
+	// earlier than the birth time moves the birth time back too.
+	if !times.BTime.IsZero() {
+		err := setBTime(name, times.BTime, followSymlinks)
+		if err != nil {
+			return errorFunc(name, err)
+		}
+	}
+
+	return nil
+}
+
+func utimeTimespec(t time.Time) unix.Timespec {
+	if t.IsZero() {
+		return unix.Timespec{Nsec: utimeOmit}
+	}
+
+	return unix.NsecToTimespec(t.UnixNano())
+}
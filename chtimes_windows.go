@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package compat
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/rasa/compat/golang"
+)
+
+const supportsBTimeSetting = true
+
+func chtimes(name string, times FileTimes, followSymlinks bool, _ ...Option) error {
+	errorFunc := chtimesError
+
+	attrs := uint32(windows.FILE_FLAG_BACKUP_SEMANTICS)
+	if !followSymlinks {
+		errorFunc = lchtimesError
+		attrs |= windows.FILE_FLAG_OPEN_REPARSE_POINT
+	}
+
+	path16, err := windows.UTF16PtrFromString(golang.FixLongPath(name))
+	if err != nil {
+		return errorFunc(name, err)
+	}
+
+	h, err := windows.CreateFile(path16, windows.FILE_WRITE_ATTRIBUTES,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, attrs, 0)
+	if err != nil {
+		return errorFunc(name, err)
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	// A nil Filetime leaves the time unchanged.
+	err = windows.SetFileTime(h, filetime(times.BTime), filetime(times.ATime), filetime(times.MTime))
+	if err != nil {
+		return errorFunc(name, err)
+	}
+
+	return nil
+}
+
+func filetime(t time.Time) *windows.Filetime {
+	if t.IsZero() {
+		return nil
+	}
+
+	ft := windows.NsecToFiletime(t.UnixNano())
+
+	return &ft
+}
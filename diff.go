@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"strings"
+	"time"
+)
+
+// Change is a set of FileInfo fields that differ, and is returned by the
+// [Diff] function.
+type Change uint
+
+const (
+	// ChangeSize is set if Size() differs.
+	ChangeSize Change = 1 << iota
+	// ChangeMode is set if Mode() differs.
+	ChangeMode
+	// ChangeMTime is set if ModTime() differs.
+	ChangeMTime
+	// ChangeCTime is set if CTime() differs.
+	ChangeCTime
+	// ChangeOwner is set if UID() or GID() differ, or if the IDs are
+	// unknown, if User() or Group() differ.
+	ChangeOwner
+	// ChangeLinks is set if Links() differs.
+	ChangeLinks
+	// ChangeIdentity is set if PartitionID() or FileID() differ, that is,
+	// if the values describe different files.
+	ChangeIdentity
+)
+
+var changeNames = []struct {
+	change Change
+	name   string
+}{
+	{ChangeSize, "size"},
+	{ChangeMode, "mode"},
+	{ChangeMTime, "mtime"},
+	{ChangeCTime, "ctime"},
+	{ChangeOwner, "owner"},
+	{ChangeLinks, "links"},
+	{ChangeIdentity, "identity"},
+}
+
+// Has returns true if all the changes in change are set.
+func (c Change) Has(change Change) bool {
+	return c&change == change
+}
+
+// String returns the changed field names, separated by a pipe (|) character.
+func (c Change) String() string {
+	names := make([]string, 0, len(changeNames))
+
+	for _, cn := range changeNames {
+		if c&cn.change != 0 {
+			names = append(names, cn.name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// Diff returns the set of fields that differ between oldFI and newFI, or 0 if
+// none differ. It works on any [FileInfo], including a [Snapshot] that was
+// unmarshaled on another OS.
+//
+// A field that is unsupported, or unknown, in either value, such as a zero
+// CTime(), a zero Links() count, or a zero FileID(), is not compared.
+// Times that differ by less than the WithTimeGranularity option are
+// considered equal.
+func Diff(oldFI, newFI FileInfo, opts ...Option) Change {
+	fopts := buildOptions(opts...)
+
+	var c Change
+
+	if oldFI.Size() != newFI.Size() {
+		c |= ChangeSize
+	}
+
+	if oldFI.Mode() != newFI.Mode() {
+		c |= ChangeMode
+	}
+
+	if timeChanged(oldFI.ModTime(), newFI.ModTime(), fopts.timeGranularity) {
+		c |= ChangeMTime
+	}
+
+	if timeChanged(oldFI.CTime(), newFI.CTime(), fopts.timeGranularity) {
+		c |= ChangeCTime
+	}
+
+	if ownerChanged(oldFI, newFI) {
+		c |= ChangeOwner
+	}
+
+	if oldFI.Links() != 0 && newFI.Links() != 0 && oldFI.Links() != newFI.Links() {
+		c |= ChangeLinks
+	}
+
+	if oldFI.FileID() != 0 && newFI.FileID() != 0 &&
+		(oldFI.PartitionID() != newFI.PartitionID() || oldFI.FileID() != newFI.FileID()) {
+		c |= ChangeIdentity
+	}
+
+	return c
+}
+
+func timeChanged(t1, t2 time.Time, granularity time.Duration) bool {
+	if t1.IsZero() || t2.IsZero() {
+		return false
+	}
+
+	if granularity <= 0 {
+		return !t1.Equal(t2)
+	}
+
+	return t1.Sub(t2).Abs() >= granularity
+}
+
+func ownerChanged(fi1, fi2 FileInfo) bool {
+	return idChanged(fi1.UID(), fi2.UID(), fi1.User, fi2.User) ||
+		idChanged(fi1.GID(), fi2.GID(), fi1.Group, fi2.Group)
+}
+
+// idChanged compares the IDs, if both are known, otherwise the names. The
+// names are only looked up if an ID is unknown, as a lookup may be slow.
+func idChanged(id1, id2 int, name1, name2 func() string) bool {
+	if id1 != UnknownID && id2 != UnknownID {
+		return id1 != id2
+	}
+
+	n1 := name1()
+	if n1 == "" {
+		return false
+	}
+
+	n2 := name2()
+	if n2 == "" {
+		return false
+	}
+
+	return n1 != n2
+}
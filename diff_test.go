@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rasa/compat"
+)
+
+const diffBase = `{"name":"a","size":5,"mode":420,` +
+	`"mtime":"2026-01-02T03:04:05Z","ctime":"2026-01-02T03:04:05Z",` +
+	`"links":1,"uid":1000,"gid":100,"user":"alice","group":"users",` +
+	`"partitionID":1,"fileID":2}`
+
+func TestDiffStat(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi1, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi2, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := compat.Diff(fi1, fi2, compat.WithTimeGranularity(0)); got != 0 {
+		t.Fatalf("Diff(): got %v, want none", got)
+	}
+
+	err = os.WriteFile(name, []byte("hello, world"), perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := fi1.ModTime().Add(-48 * time.Hour)
+
+	err = compat.Chtimes(name, compat.FileTimes{MTime: mtime})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi3, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := compat.Diff(compat.NewSnapshot(fi1), fi3, compat.WithTimeGranularity(0))
+
+	want := compat.ChangeSize | compat.ChangeMTime
+	if got&^compat.ChangeCTime != want {
+		t.Fatalf("Diff(): got %v, want %v", got, want)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		granularity time.Duration
+		want        compat.Change
+	}{
+		{"same", diffBase, 0, 0},
+		{"size", `{"size":6}`, 0, compat.ChangeSize},
+		{"mode", `{"mode":384}`, 0, compat.ChangeMode},
+		{"mtime", `{"mtime":"2026-01-02T03:04:06Z"}`, 0, compat.ChangeMTime},
+		{"mtime within granularity", `{"mtime":"2026-01-02T03:04:06Z"}`, 2 * time.Second, 0},
+		{"mtime at granularity", `{"mtime":"2026-01-02T03:04:07Z"}`, 2 * time.Second, compat.ChangeMTime},
+		{"mtime unsupported", `{"mtime":"0001-01-01T00:00:00Z"}`, 0, 0},
+		{"ctime", `{"ctime":"2026-01-02T03:04:06Z"}`, 0, compat.ChangeCTime},
+		{"uid", `{"uid":1001}`, 0, compat.ChangeOwner},
+		{"gid", `{"gid":101}`, 0, compat.ChangeOwner},
+		{"user name only", `{"uid":-1,"user":"alice"}`, 0, 0},
+		{"user name changed", `{"uid":-1,"user":"bob"}`, 0, compat.ChangeOwner},
+		{"links", `{"links":2}`, 0, compat.ChangeLinks},
+		{"links unsupported", `{"links":0}`, 0, 0},
+		{"partition", `{"partitionID":3}`, 0, compat.ChangeIdentity},
+		{"file", `{"fileID":3}`, 0, compat.ChangeIdentity},
+		{"file unsupported", `{"fileID":0}`, 0, 0},
+		{"size and mode", `{"size":6,"mode":384}`, 0, compat.ChangeSize | compat.ChangeMode},
+	}
+
+	oldFI := snapshotFromJSON(t, diffBase)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newFI := snapshotFromJSON(t, mergeJSON(t, diffBase, tt.json))
+
+			got := compat.Diff(oldFI, newFI, compat.WithTimeGranularity(tt.granularity))
+			if got != tt.want {
+				t.Fatalf("Diff(): got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// nameCounter is a FileInfo that counts the User() and Group() lookups.
+type nameCounter struct {
+	compat.FileInfo
+
+	lookups *int
+}
+
+func (fi nameCounter) User() string {
+	*fi.lookups++
+
+	return fi.FileInfo.User()
+}
+
+func (fi nameCounter) Group() string {
+	*fi.lookups++
+
+	return fi.FileInfo.Group()
+}
+
+func TestDiffSkipsNameLookups(t *testing.T) {
+	var lookups int
+
+	oldFI := nameCounter{snapshotFromJSON(t, diffBase), &lookups}
+	newFI := nameCounter{snapshotFromJSON(t, mergeJSON(t, diffBase, `{"uid":1001}`)), &lookups}
+
+	if got := compat.Diff(oldFI, newFI); got != compat.ChangeOwner {
+		t.Fatalf("Diff(): got %q, want %q", got, compat.ChangeOwner)
+	}
+
+	if lookups != 0 {
+		t.Fatalf("got %d name lookups, want 0, as both IDs are known", lookups)
+	}
+
+	newFI = nameCounter{snapshotFromJSON(t, mergeJSON(t, diffBase, `{"uid":-1}`)), &lookups}
+
+	if got := compat.Diff(oldFI, newFI); got != 0 {
+		t.Fatalf("Diff(): got %q, want none", got)
+	}
+
+	if lookups != 2 {
+		t.Fatalf("got %d name lookups, want 2, as a UID is unknown", lookups)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	c := compat.ChangeSize | compat.ChangeOwner | compat.ChangeIdentity
+
+	if got, want := c.String(), "size|owner|identity"; got != want {
+		t.Fatalf("String(): got %q, want %q", got, want)
+	}
+
+	if !c.Has(compat.ChangeSize | compat.ChangeOwner) {
+		t.Fatal("Has(): got false, want true")
+	}
+
+	if c.Has(compat.ChangeMode) {
+		t.Fatal("Has(): got true, want false")
+	}
+}
+
+func snapshotFromJSON(t *testing.T, data string) *compat.Snapshot {
+	t.Helper()
+
+	var s compat.Snapshot
+
+	err := json.Unmarshal([]byte(data), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &s
+}
+
+// mergeJSON returns base with the fields of override replaced.
+func mergeJSON(t *testing.T, base, override string) string {
+	t.Helper()
+
+	m := map[string]any{}
+
+	for _, data := range []string{base, override} {
+		err := json.Unmarshal([]byte(data), &m)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
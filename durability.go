@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+)
+
+// syncDir fsyncs the named directory. Tests replace it to record the calls.
+var syncDir = syncDirOS
+
+// syncParents fsyncs the directories containing names, once each, if
+// durable is true. Filesystems that can't sync a directory are ignored.
+// If there is an error, it will be of type [*PathError].
+func syncParents(durable bool, names ...string) error {
+	if !durable {
+		return nil
+	}
+
+	synced := make([]string, 0, len(names))
+
+	for _, name := range names {
+		dir := filepath.Dir(name)
+
+		if slices.Contains(synced, dir) {
+			continue
+		}
+
+		synced = append(synced, dir)
+
+		err := syncDir(dir)
+		if err != nil && !isSyncDirUnsupported(err) {
+			return syncError(dir, err)
+		}
+	}
+
+	return nil
+}
+
+// willCreate returns true if durable is true, and opening name with flag will
+// create it, so the directory containing it needs to be synced. Without
+// O_EXCL, it checks whether name exists, so a sync is skipped when an
+// existing file is opened.
+func willCreate(name string, flag int, durable bool) bool {
+	if !durable || flag&os.O_CREATE == 0 {
+		return false
+	}
+
+	if flag&os.O_EXCL != 0 {
+		return true
+	}
+
+	_, err := os.Stat(name)
+
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// missingDirs returns path, and its parents, up to the first one that exists,
+// so the directories containing them can be synced once they are created.
+func missingDirs(path string) []string {
+	var names []string
+
+	for dir := filepath.Clean(path); ; {
+		_, err := os.Lstat(dir)
+		if !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+
+		names = append(names, dir)
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	return names
+}
+
+// syncCreated fsyncs the directory containing the newly created file f, if
+// durable is true. If the sync fails, f is closed.
+func syncCreated(f *os.File, durable bool) (*os.File, error) {
+	if f == nil {
+		return f, nil
+	}
+
+	err := syncParents(durable, f.Name())
+	if err != nil {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// isSyncDirUnsupported returns true if err reports that the filesystem, or
+// OS, can't sync a directory.
+func isSyncDirUnsupported(err error) bool {
+	return IsUnsupportedError(err) || errors.Is(err, syscall.EINVAL)
+}
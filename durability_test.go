@@ -0,0 +1,391 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+// recordSyncDir replaces the directory sync function with one that records
+// the directories synced, and returns err.
+func recordSyncDir(t *testing.T, err error) *[]string {
+	t.Helper()
+
+	var synced []string
+
+	restore := compat.SetSyncDir(func(dir string) error {
+		synced = append(synced, dir)
+
+		return err
+	})
+	t.Cleanup(restore)
+
+	return &synced
+}
+
+func assertSynced(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	for i := range want {
+		want[i] = filepath.Clean(want[i])
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("synced %q, want %q", got, want)
+	}
+}
+
+func TestDurabilityWriteFileAtomic(t *testing.T) {
+	dir := tempDir(t)
+	name := filepath.Join(dir, "file.txt")
+	synced := recordSyncDir(t, nil)
+
+	err := compat.WriteFile(name, helloBytes, perm600, compat.WithAtomicity(true), compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dir)
+}
+
+func TestDurabilityWriteFileNonAtomic(t *testing.T) {
+	dir := tempDir(t)
+	name := filepath.Join(dir, "file.txt")
+	synced := recordSyncDir(t, nil)
+
+	err := compat.WriteFile(name, helloBytes, perm600, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dir)
+}
+
+func TestDurabilityRenameAcrossDirs(t *testing.T) {
+	src, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstDir := tempDir(t)
+	dst := filepath.Join(dstDir, "renamed.txt")
+	synced := recordSyncDir(t, nil)
+
+	err = compat.Rename(src, dst, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dstDir, filepath.Dir(src))
+}
+
+func TestDurabilityLink(t *testing.T) {
+	if !supportsHardLinks(t) {
+		return
+	}
+
+	src, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstDir := tempDir(t)
+	dst := filepath.Join(dstDir, "link.txt")
+	synced := recordSyncDir(t, nil)
+
+	err = compat.Link(src, dst, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dstDir)
+}
+
+func TestDurabilitySymlink(t *testing.T) {
+	if !supportsSymlinks(t) {
+		return
+	}
+
+	src, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstDir := tempDir(t)
+	dst := filepath.Join(dstDir, "symlink.txt")
+	synced := recordSyncDir(t, nil)
+
+	err = compat.Symlink(src, dst, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dstDir)
+}
+
+func TestDurabilityRemove(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	synced := recordSyncDir(t, nil)
+
+	err = compat.Remove(name, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, filepath.Dir(name))
+}
+
+func TestDurabilityRemoveAll(t *testing.T) {
+	parent := tempDir(t)
+	dir := filepath.Join(parent, "dir")
+
+	err := os.MkdirAll(filepath.Join(dir, "child"), perm700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	synced := recordSyncDir(t, nil)
+
+	err = compat.RemoveAll(dir, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A path that doesn't exist isn't synced.
+	err = compat.RemoveAll(filepath.Join(parent, "missing", "dir"), compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, parent)
+}
+
+func TestDurabilityGlobal(t *testing.T) {
+	dir := tempDir(t)
+	synced := recordSyncDir(t, nil)
+
+	compat.SetOptions(compat.WithDurability(true))
+	t.Cleanup(func() { compat.SetOptions(compat.WithDurability(false)) })
+
+	err := compat.Mkdir(filepath.Join(dir, "dir"), perm700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.WriteFile(filepath.Join(dir, "file.txt"), helloBytes, perm600, compat.WithAtomicity(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dir, dir)
+}
+
+func TestDurabilityCreate(t *testing.T) {
+	dir := tempDir(t)
+	synced := recordSyncDir(t, nil)
+
+	f, err := compat.Create(filepath.Join(dir, "create.txt"), compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	f2, err := compat.CreateTemp(dir, "", compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f2.Close()
+
+	assertSynced(t, *synced, dir, dir)
+}
+
+func TestDurabilityCreateExisting(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	synced := recordSyncDir(t, nil)
+
+	f, err := compat.Create(name, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	assertSynced(t, *synced)
+}
+
+func TestDurabilityOpenFile(t *testing.T) {
+	dir := tempDir(t)
+	name := filepath.Join(dir, "open.txt")
+	synced := recordSyncDir(t, nil)
+
+	f, err := compat.OpenFile(name, os.O_CREATE|os.O_WRONLY, perm600, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	assertSynced(t, *synced, dir)
+
+	f, err = compat.OpenFile(name, os.O_RDONLY, 0, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	assertSynced(t, *synced, dir)
+
+	// the file exists, so O_CREATE doesn't create it.
+	f, err = compat.OpenFile(name, os.O_CREATE|os.O_WRONLY, perm600, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	assertSynced(t, *synced, dir)
+}
+
+func TestDurabilityOpenFileExclusive(t *testing.T) {
+	dir := tempDir(t)
+	name := filepath.Join(dir, "open.txt")
+	synced := recordSyncDir(t, nil)
+
+	f, err := compat.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm600, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	assertSynced(t, *synced, dir)
+}
+
+func TestDurabilityMkdir(t *testing.T) {
+	dir := tempDir(t)
+	synced := recordSyncDir(t, nil)
+
+	err := compat.Mkdir(filepath.Join(dir, "mkdir"), perm700, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dir)
+}
+
+func TestDurabilityMkdirAll(t *testing.T) {
+	dir := tempDir(t)
+	a := filepath.Join(dir, "a")
+	b := filepath.Join(a, "b")
+	synced := recordSyncDir(t, nil)
+
+	err := compat.MkdirAll(filepath.Join(b, "c"), perm700, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, b, a, dir)
+
+	// the directories exist, so nothing is synced.
+	err = compat.MkdirAll(filepath.Join(b, "c"), perm700, compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, b, a, dir)
+}
+
+func TestDurabilityMkdirTemp(t *testing.T) {
+	dir := tempDir(t)
+	synced := recordSyncDir(t, nil)
+
+	_, err := compat.MkdirTemp(dir, "", compat.WithDurability(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced, dir)
+}
+
+func TestDurabilityDisabled(t *testing.T) {
+	dir := tempDir(t)
+	name := filepath.Join(dir, "file.txt")
+	synced := recordSyncDir(t, nil)
+
+	err := compat.WriteFile(name, helloBytes, perm600, compat.WithAtomicity(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.Rename(name, name+".new")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.Remove(name + ".new")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSynced(t, *synced)
+}
+
+func TestDurabilitySyncError(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = recordSyncDir(t, syscall.EIO)
+
+	err = compat.Rename(name, name+".new", compat.WithDurability(true))
+
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) || pathErr.Op != "sync" {
+		t.Fatalf("got %v, want a sync *os.PathError", err)
+	}
+
+	if !errors.Is(err, syscall.EIO) {
+		t.Fatalf("got %v, want %v", err, syscall.EIO)
+	}
+}
+
+func TestDurabilitySyncUnsupported(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	synced := recordSyncDir(t, &compat.UnsupportedError{Op: "fsync"})
+
+	err = compat.Rename(name, name+".new", compat.WithDurability(true))
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+
+	assertSynced(t, *synced, filepath.Dir(name))
+}
+
+func TestDurabilitySyncDirOS(t *testing.T) {
+	err := compat.SyncDirOS(tempDir(t))
+	if err != nil && !compat.IsUnsupportedError(err) && !errors.Is(err, syscall.EINVAL) {
+		t.Fatal(err)
+	}
+}
@@ -34,6 +34,35 @@ func (e *UnimplementedError) Unwrap() error {
 	return errors.ErrUnsupported
 }
 
+// The Field values used in a FieldError.
+const (
+	FieldMode  = "mode"
+	FieldBTime = "btime"
+	FieldCTime = "ctime"
+	FieldUID   = "uid"
+	FieldGID   = "gid"
+	FieldUser  = "user"
+	FieldGroup = "group"
+	FieldCaps  = "caps"
+)
+
+// FieldError records a failed lazy lookup of a FileInfo field, such as
+// BTime(), or User(), and is returned by FileInfo's Error() and Errors()
+// functions.
+type FieldError struct {
+	Field string // the field that failed, such as FieldBTime, or FieldUser
+	Op    string // the operation that failed, such as "statx", or "lookup"
+	Err   error  // the underlying error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Op + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 func unsupportedError(prefix string) error {
 	return &UnsupportedError{prefix}
 }
@@ -42,10 +71,18 @@ func unimplementedError(prefix string) error {
 	return &UnimplementedError{prefix}
 }
 
+func chtimesError(path string, err error) error {
+	return &os.PathError{Op: "chtimes", Path: path, Err: err}
+}
+
 func chmodError(path string, err error) error {
 	return &os.PathError{Op: "chmod", Path: path, Err: err}
 }
 
+func chownError(path string, err error) error {
+	return &os.PathError{Op: "chown", Path: path, Err: err}
+}
+
 func createError(path string, err error) error {
 	return &os.PathError{Op: "create", Path: path, Err: err}
 }
@@ -54,6 +91,22 @@ func createTempError(path string, err error) error {
 	return &os.PathError{Op: "createtemp", Path: path, Err: err}
 }
 
+func fileHandleError(path string, err error) error {
+	return &os.PathError{Op: "name_to_handle_at", Path: path, Err: err}
+}
+
+func hashError(path string, err error) error {
+	return &os.PathError{Op: "hash", Path: path, Err: err}
+}
+
+func lchtimesError(path string, err error) error {
+	return &os.PathError{Op: "lchtimes", Path: path, Err: err}
+}
+
+func lchownError(path string, err error) error {
+	return &os.PathError{Op: "lchown", Path: path, Err: err}
+}
+
 func mkdirError(path string, err error) error {
 	return &os.PathError{Op: "mkdir", Path: path, Err: err}
 }
@@ -66,6 +119,10 @@ func mkdirTempError(path string, err error) error {
 	return &os.PathError{Op: "mkdirtemp", Path: path, Err: err}
 }
 
+func openByHandleError(path string, err error) error {
+	return &os.PathError{Op: "open_by_handle_at", Path: path, Err: err}
+}
+
 func openError(path string, err error) error {
 	return &os.PathError{Op: "open", Path: path, Err: err}
 }
@@ -74,10 +131,18 @@ func renameError(old, gnu string, err error) error {
 	return &os.LinkError{Op: "rename", Old: old, New: gnu, Err: err}
 }
 
+func setHiddenError(path string, err error) error {
+	return &os.PathError{Op: "sethidden", Path: path, Err: err}
+}
+
 func statError(path string, err error) error {
 	return &os.PathError{Op: "stat", Path: path, Err: err}
 }
 
+func syncError(path string, err error) error {
+	return &os.PathError{Op: "sync", Path: path, Err: err}
+}
+
 func symlinkError(old, gnu string, err error) error {
 	return &os.LinkError{Op: "symlink", Old: old, New: gnu, Err: err}
 }
@@ -166,3 +166,51 @@ func TestErrorsWriteError(t *testing.T) {
 		t.Fatalf("WriteError: got %q; want %q", got, want)
 	}
 }
+
+func TestErrorsFieldError(t *testing.T) {
+	err := &compat.FieldError{Field: compat.FieldBTime, Op: "statx", Err: os.ErrPermission}
+	got := err.Error()
+
+	want := "btime: statx: " + os.ErrPermission.Error()
+	if got != want {
+		t.Fatalf("FieldError: got %q; want %q", got, want)
+	}
+
+	if !errors.Is(err, os.ErrPermission) {
+		t.Fatalf("FieldError: got %v, want ErrPermission", err)
+	}
+
+	var fe *compat.FieldError
+
+	wrapped := errors.Join(errors.New("other"), err)
+	if !errors.As(wrapped, &fe) || fe.Field != compat.FieldBTime {
+		t.Fatalf("FieldError: got %v, want a *FieldError for %v", wrapped, compat.FieldBTime)
+	}
+}
+
+func TestErrorsFileInfoNoErrors(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	err = fi.Error()
+	if err != nil {
+		t.Fatalf("Error(): got %v, want nil", err)
+	}
+
+	// a filesystem without birth times records an unsupported BTime().
+	err = fi.Errors()
+	if err != nil && (!compat.IsUnsupportedError(err) || !fi.BTime().IsZero()) {
+		t.Fatalf("Errors(): got %v, want nil", err)
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build unix && !android
+
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"strconv"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+// unknownID is a UID and GID that is very unlikely to exist.
+const unknownID = 54321
+
+func TestErrorsFileInfoFieldErrors(t *testing.T) {
+	if _, err := user.LookupId(strconv.Itoa(unknownID)); err == nil {
+		skipf(t, "Skipping test: user ID %v exists", unknownID)
+
+		return
+	}
+
+	if _, err := user.LookupGroupId(strconv.Itoa(unknownID)); err == nil {
+		skipf(t, "Skipping test: group ID %v exists", unknownID)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	err = os.Chown(name, unknownID, unknownID)
+	if err != nil {
+		skipf(t, "Skipping test: cannot chown %v: %v", name, err)
+
+		return
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	_ = fi.User()
+	_ = fi.Group()
+
+	var fe *compat.FieldError
+	if !errors.As(fi.Error(), &fe) || fe.Field != compat.FieldGroup {
+		t.Fatalf("Error(): got %v, want a *FieldError for %v", fi.Error(), compat.FieldGroup)
+	}
+
+	joined, ok := fi.Errors().(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Errors(): got %T, want a joined error", fi.Errors())
+	}
+
+	fields := map[string]bool{}
+
+	for _, err := range joined.Unwrap() {
+		if errors.As(err, &fe) {
+			fields[fe.Field] = true
+		}
+	}
+
+	if !fields[compat.FieldUser] || !fields[compat.FieldGroup] {
+		t.Fatalf("Errors(): got %v, want errors for %v and %v", fi.Errors(), compat.FieldUser, compat.FieldGroup)
+	}
+
+	var uerr user.UnknownUserIdError
+	if !errors.As(fi.Errors(), &uerr) {
+		t.Fatalf("Errors(): got %v, want a user.UnknownUserIdError", fi.Errors())
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat
+
+import (
+	"os"
+)
+
+// stat_statx_linux.go
+
+var StatxUnavailable = &statxUnavailable
+
+var StatxMode = statxMode
+
+var StatxAttributes = statxAttributes
+
+// handle_linux.go
+
+var UnescapeMountInfo = unescapeMountInfo
+
+// atomicwriter_linux.go
+
+var OpenTmpFileOS = openTmpFileOS
+
+// SetOpenTmpFile replaces the function that opens an unnamed O_TMPFILE
+// file, and returns a function that restores it.
+func SetOpenTmpFile(fn func(dir string, perm os.FileMode, flag int) (*os.File, error)) func() {
+	saved := openTmpFile
+	openTmpFile = fn
+
+	return func() { openTmpFile = saved }
+}
@@ -11,14 +11,29 @@ var FSFileInfoToDirEntry = fsFileInfoToDirEntry
 
 var OSDirEntryToDirEntry = osDirEntryToDirEntry
 
+// durability.go
+
+var SyncDirOS = syncDirOS
+
+// SetSyncDir replaces the function that syncs a directory, and returns a
+// function that restores it.
+func SetSyncDir(fn func(dir string) error) func() {
+	saved := syncDir
+	syncDir = fn
+
+	return func() { syncDir = saved }
+}
+
 // errors.go
 
 var (
 	ExportedUnsupportedError   = unsupportedError
 	ExportedUnimplementedError = unimplementedError
 	ChmodError                 = chmodError
+	ChtimesError               = chtimesError
 	CreateError                = createError
 	CreateTempError            = createTempError
+	LchtimesError              = lchtimesError
 	MkdirError                 = mkdirError
 	MkdirallError              = mkdirallError
 	MkdirTempError             = mkdirTempError
@@ -33,6 +48,12 @@ var (
 
 var BuildOptions = buildOptions
 
+// rename.go
+
+var RenameNoReplace = renameNoReplace
+
+var RenameNoReplaceLink = renameNoReplaceLink
+
 // runtime.go
 
 var ExportedGoVersion = goVersion
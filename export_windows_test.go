@@ -49,8 +49,6 @@ var GetFileOwnerAndGroupSIDs = getFileOwnerAndGroupSIDs
 
 var GetPrimaryDomainSID = getPrimaryDomainSID
 
-var GetRID = getRID
-
 var GetUserGroup = getUserGroup
 
 var IsValidSid = isValidSid
@@ -59,4 +57,8 @@ var LSAOpenPolicy = lsaOpenPolicy
 
 var NameFromSID = nameFromSID
 
+var PosixIDToSID = posixIDToSID
+
+var SIDMapper = sidMapper
+
 var SIDToPOSIXID = sidToPOSIXID
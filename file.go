@@ -37,7 +37,14 @@ func Chmod(name string, mode os.FileMode, opts ...Option) error {
 // The directory containing the file must already exist.
 // If there is an error, it will be of type [*PathError].
 func Create(name string, opts ...Option) (*os.File, error) {
-	return create(name, opts...)
+	created := willCreate(name, os.O_CREATE, buildOptions(opts...).durable)
+
+	f, err := create(name, opts...)
+	if err != nil || !created {
+		return f, err
+	}
+
+	return syncCreated(f, true)
 }
 
 // CreateTemp creates a new temporary file in the directory dir,
@@ -69,7 +76,12 @@ func CreateTemp(dir, pattern string, opts ...Option) (*os.File, error) {
 		}
 	}
 
-	return createTemp(dir, pattern, fopts.fileMode, fopts.flags)
+	f, err := createTemp(dir, pattern, fopts.fileMode, fopts.flags)
+	if err != nil {
+		return f, err
+	}
+
+	return syncCreated(f, fopts.durable)
 }
 
 // Fchmod changes the mode of the file to mode.
@@ -81,8 +93,13 @@ func Fchmod(f *os.File, mode os.FileMode, opts ...Option) error {
 // Mkdir creates a new directory with the specified name and perm's permission
 // bits (before umask).
 // If there is an error, it will be of type [*PathError].
-func Mkdir(name string, perm os.FileMode) error {
-	return mkdir(name, perm)
+func Mkdir(name string, perm os.FileMode, opts ...Option) error {
+	err := mkdir(name, perm)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(buildOptions(opts...).durable, name)
 }
 
 // MkdirAll creates a directory named path,
@@ -92,8 +109,20 @@ func Mkdir(name string, perm os.FileMode) error {
 // directories that MkdirAll creates.
 // If path is already a directory, MkdirAll does nothing
 // and returns nil.
-func MkdirAll(path string, perm os.FileMode) error {
-	return mkdirAll(path, perm)
+func MkdirAll(path string, perm os.FileMode, opts ...Option) error {
+	durable := buildOptions(opts...).durable
+
+	var created []string
+	if durable {
+		created = missingDirs(path)
+	}
+
+	err := mkdirAll(path, perm)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(durable, created...)
 }
 
 // MkdirTemp creates a new temporary directory in the directory dir
@@ -105,7 +134,17 @@ func MkdirAll(path string, perm os.FileMode) error {
 // Multiple programs or goroutines calling MkdirTemp simultaneously will not choose the same directory.
 // It is the caller's responsibility to remove the directory when it is no longer needed.
 func MkdirTemp(dir, pattern string, opts ...Option) (string, error) {
-	return mkdirTemp(dir, pattern, opts...)
+	name, err := mkdirTemp(dir, pattern, opts...)
+	if err != nil {
+		return name, err
+	}
+
+	err = syncParents(buildOptions(opts...).durable, name)
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
 }
 
 // OpenFile is the generalized open call; most users will use Open
@@ -130,13 +169,25 @@ func OpenFile(name string, flag int, perm os.FileMode, opts ...Option) (*os.File
 		}
 	}
 
-	return openFile(name, fopts.flags, fopts.fileMode)
+	created := willCreate(name, fopts.flags, fopts.durable)
+
+	f, err := openFile(name, fopts.flags, fopts.fileMode)
+	if err != nil || !created {
+		return f, err
+	}
+
+	return syncCreated(f, true)
 }
 
 // Remove removes the named file or directory.
 // If there is an error, it will be of type [*PathError].
-func Remove(name string) error {
-	return remove(name)
+func Remove(name string, opts ...Option) error {
+	err := remove(name)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(buildOptions(opts...).durable, name)
 }
 
 // RemoveAll removes path and any children it contains.
@@ -145,7 +196,20 @@ func Remove(name string) error {
 // returns nil (no error).
 // If there is an error, it will be of type [*PathError].
 func RemoveAll(path string, opts ...Option) error {
-	return removeAll(path, opts...)
+	durable := buildOptions(opts...).durable
+
+	// Only sync the directory containing path if there was something to remove.
+	if durable {
+		_, err := os.Lstat(path)
+		durable = err == nil
+	}
+
+	err := removeAll(path, opts...)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(durable, path)
 }
 
 // Symlink creates newname as a symbolic link to oldname.
@@ -153,5 +217,10 @@ func RemoveAll(path string, opts ...Option) error {
 // if oldname is later created as a directory the symlink will not work.
 // If there is an error, it will be of type *LinkError.
 func Symlink(oldname, newname string, opts ...Option) error {
-	return symlink(oldname, newname, opts...)
+	err := symlink(oldname, newname, opts...)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(buildOptions(opts...).durable, newname)
 }
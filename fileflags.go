@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"os"
+	"strings"
+)
+
+// FileFlags is a set of file flags, such as immutable, or hidden, that are
+// stored outside of the file's mode bits. Use SupportedFileFlags to see which
+// flags the OS supports:
+//
+//	Linux:       immutable, append, nodump, noatime (via FS_IOC_GETFLAGS/FS_IOC_SETFLAGS, see chattr(1))
+//	macOS:       immutable, append, nodump, hidden (via chflags(2))
+//	FreeBSD:     immutable, append, nodump, hidden, system, archive (via chflags(2))
+//	NetBSD, OpenBSD, DragonFly BSD: immutable, append, nodump (via chflags(2))
+//	Windows:     hidden, system, archive (file attributes)
+//	Plan 9:      append, exclusive (ModeAppend and ModeExclusive)
+//
+// The immutable and append flags, and on BSD systems, the nodump flag, are
+// the user settable versions (UF_*), not the superuser versions (SF_*).
+type FileFlags uint32
+
+const (
+	// FlagImmutable is set if the file cannot be modified, deleted, or
+	// renamed.
+	FlagImmutable FileFlags = 1 << iota
+	// FlagAppend is set if the file can only be appended to.
+	FlagAppend
+	// FlagNoDump is set if the file should not be backed up by dump(8).
+	FlagNoDump
+	// FlagNoATime is set if the file's access time is not updated.
+	FlagNoATime
+	// FlagHidden is set if the file is hidden from directory listings.
+	FlagHidden
+	// FlagSystem is set if the file is a system file.
+	FlagSystem
+	// FlagArchive is set if the file should be archived.
+	FlagArchive
+	// FlagExclusive is set if the file can only be opened by one client at
+	// a time.
+	FlagExclusive
+)
+
+var fileFlagNames = []struct {
+	flag FileFlags
+	name string
+}{
+	{FlagImmutable, "immutable"},
+	{FlagAppend, "append"},
+	{FlagNoDump, "nodump"},
+	{FlagNoATime, "noatime"},
+	{FlagHidden, "hidden"},
+	{FlagSystem, "system"},
+	{FlagArchive, "archive"},
+	{FlagExclusive, "exclusive"},
+}
+
+// Has returns true if all the flags in flags are set.
+func (f FileFlags) Has(flags FileFlags) bool {
+	return f&flags == flags
+}
+
+// String returns the flag names, separated by a pipe (|) character.
+func (f FileFlags) String() string {
+	names := make([]string, 0, len(fileFlagNames))
+
+	for _, fn := range fileFlagNames {
+		if f&fn.flag != 0 {
+			names = append(names, fn.name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// GetFileFlags returns the flags of the named file. Only the flags in
+// SupportedFileFlags() are returned.
+// If there is an error, it will be of type [*PathError].
+func GetFileFlags(name string) (FileFlags, error) {
+	return getFileFlags(name)
+}
+
+// SetFileFlags sets the flags of the named file to flags. Supported flags
+// not in flags are cleared, and flags that aren't reported by GetFileFlags are
+// left unchanged. Setting the immutable, append, and system flags may require
+// elevated privileges.
+// If flags has a flag that isn't in SupportedFileFlags(), the error wraps
+// an *UnsupportedError, and no flags are changed. On systems without file
+// flags, the error always wraps an *UnsupportedError, even if flags is 0.
+// If there is an error, it will be of type [*PathError].
+func SetFileFlags(name string, flags FileFlags) error {
+	if unsupported := flags &^ supportedFileFlags; unsupported != 0 {
+		return setFlagsError(name, &UnsupportedError{Op: unsupported.String()})
+	}
+
+	return setFileFlags(name, flags)
+}
+
+// SupportedFileFlags returns the file flags supported by the OS.
+func SupportedFileFlags() FileFlags {
+	return supportedFileFlags
+}
+
+func getFlagsError(path string, err error) error {
+	return &os.PathError{Op: "getflags", Path: path, Err: err}
+}
+
+func setFlagsError(path string, err error) error {
+	return &os.PathError{Op: "setflags", Path: path, Err: err}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+// The darwin build flag includes ios
+
+package compat
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// The user settable chflags(2) flags, which have the same values on every
+// BSD. See https://github.com/freebsd/freebsd-src/blob/release/14.1.0/sys/sys/stat.h#L274
+const (
+	ufNoDump    = 0x00000001
+	ufImmutable = 0x00000002
+	ufAppend    = 0x00000004
+)
+
+type bsdFlag struct {
+	flag FileFlags
+	uf   uint32
+}
+
+var bsdFlagMap = append([]bsdFlag{
+	{FlagImmutable, ufImmutable},
+	{FlagAppend, ufAppend},
+	{FlagNoDump, ufNoDump},
+}, bsdExtraFlags...)
+
+func getFileFlags(name string) (FileFlags, error) {
+	var st unix.Stat_t
+
+	err := unix.Stat(name, &st)
+	if err != nil {
+		return 0, getFlagsError(name, err)
+	}
+
+	var flags FileFlags
+
+	for _, m := range bsdFlagMap {
+		if uint32(st.Flags)&m.uf != 0 { //nolint:unconvert,nolintlint // Flags is an int32 on some systems
+			flags |= m.flag
+		}
+	}
+
+	return flags, nil
+}
+
+func setFileFlags(name string, flags FileFlags) error {
+	var st unix.Stat_t
+
+	err := unix.Stat(name, &st)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	uf := uint32(st.Flags) //nolint:unconvert,nolintlint // Flags is an int32 on some systems
+	for _, m := range bsdFlagMap {
+		uf &^= m.uf
+		if flags&m.flag != 0 {
+			uf |= m.uf
+		}
+	}
+
+	err = unix.Chflags(name, int(uf))
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build dragonfly || netbsd || openbsd
+
+package compat
+
+const supportedFileFlags = FlagImmutable | FlagAppend | FlagNoDump
+
+var bsdExtraFlags = []bsdFlag{}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin
+
+package compat
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+const ufHidden = unix.UF_HIDDEN
+
+const supportedFileFlags = FlagImmutable | FlagAppend | FlagNoDump | FlagHidden
+
+var bsdExtraFlags = []bsdFlag{
+	{FlagHidden, ufHidden},
+}
+
+// lchflags sets the flags on name, without following a symbolic link.
+// x/sys/unix has no Lchflags on macOS, so the link itself is opened with
+// O_SYMLINK. O_EVTONLY, like chflags(2), doesn't need read permission.
+func lchflags(name string, flags uint32) error {
+	fd, err := unix.Open(name, unix.O_EVTONLY|unix.O_SYMLINK|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd) //nolint:errcheck
+
+	return unix.Fchflags(fd, int(flags))
+}
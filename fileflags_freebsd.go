@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build freebsd
+
+package compat
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// See https://github.com/freebsd/freebsd-src/blob/release/14.1.0/sys/sys/stat.h#L283
+const (
+	ufSystem  = 0x00000080
+	ufArchive = 0x00000800
+	ufHidden  = 0x00008000
+)
+
+const supportedFileFlags = FlagImmutable | FlagAppend | FlagNoDump | FlagHidden | FlagSystem | FlagArchive
+
+var bsdExtraFlags = []bsdFlag{
+	{FlagHidden, ufHidden},
+	{FlagSystem, ufSystem},
+	{FlagArchive, ufArchive},
+}
+
+// lchflags sets the flags on name, without following a symbolic link.
+// x/sys/unix has no Lchflags, so lchflags(2) is called directly.
+func lchflags(name string, flags uint32) error {
+	p, err := unix.BytePtrFromString(name)
+	if err != nil {
+		return err
+	}
+
+	_, _, errno := unix.Syscall(unix.SYS_LCHFLAGS, uintptr(unsafe.Pointer(p)), uintptr(flags), 0)
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
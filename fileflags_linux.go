@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package compat
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// See https://github.com/torvalds/linux/blob/v6.12/include/uapi/linux/fs.h#L283
+const (
+	fsImmutableFl = 0x00000010
+	fsAppendFl    = 0x00000020
+	fsNoDumpFl    = 0x00000040
+	fsNoATimeFl   = 0x00000080
+)
+
+const supportedFileFlags = FlagImmutable | FlagAppend | FlagNoDump | FlagNoATime
+
+var linuxFlagMap = []struct {
+	flag FileFlags
+	fl   uint32
+}{
+	{FlagImmutable, fsImmutableFl},
+	{FlagAppend, fsAppendFl},
+	{FlagNoDump, fsNoDumpFl},
+	{FlagNoATime, fsNoATimeFl},
+}
+
+func getFileFlags(name string) (FileFlags, error) {
+	var fl uint32
+
+	err := withFlagsFD(name, func(fd int) error {
+		var err error
+
+		fl, err = unix.IoctlGetUint32(fd, unix.FS_IOC_GETFLAGS)
+
+		return err
+	})
+	if err != nil {
+		return 0, getFlagsError(name, mapFlagsError(err))
+	}
+
+	var flags FileFlags
+
+	for _, m := range linuxFlagMap {
+		if fl&m.fl != 0 {
+			flags |= m.flag
+		}
+	}
+
+	return flags, nil
+}
+
+func setFileFlags(name string, flags FileFlags) error {
+	err := withFlagsFD(name, func(fd int) error {
+		fl, err := unix.IoctlGetUint32(fd, unix.FS_IOC_GETFLAGS)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range linuxFlagMap {
+			fl &^= m.fl
+			if flags&m.flag != 0 {
+				fl |= m.fl
+			}
+		}
+
+		return unix.IoctlSetPointerInt(fd, unix.FS_IOC_SETFLAGS, int(int32(fl))) //nolint:gosec // intentional uint32 → int32 conversion
+	})
+	if err != nil {
+		return setFlagsError(name, mapFlagsError(err))
+	}
+
+	return nil
+}
+
+// withFlagsFD opens name the way chattr(1) does, and calls fn with the
+// file descriptor.
+func withFlagsFD(name string, fn func(fd int) error) error {
+	fd, err := unix.Open(name, unix.O_RDONLY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd) //nolint:errcheck
+
+	return fn(fd)
+}
+
+// mapFlagsError maps the errno values returned by filesystems that don't
+// support the FS_IOC_GETFLAGS/FS_IOC_SETFLAGS ioctls to an UnsupportedError.
+func mapFlagsError(err error) error {
+	if errors.Is(err, unix.ENOTTY) || errors.Is(err, unix.ENOTSUP) {
+		return &UnsupportedError{Op: "ioctl"}
+	}
+
+	return err
+}
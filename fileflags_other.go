@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || plan9 || windows)
+
+package compat
+
+const supportedFileFlags = FileFlags(0)
+
+func getFileFlags(name string) (FileFlags, error) {
+	return 0, getFlagsError(name, &UnsupportedError{Op: "getflags"})
+}
+
+func setFileFlags(name string, _ FileFlags) error {
+	return setFlagsError(name, &UnsupportedError{Op: "setflags"})
+}
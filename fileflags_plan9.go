@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build plan9
+
+package compat
+
+import (
+	"os"
+)
+
+const supportedFileFlags = FlagAppend | FlagExclusive
+
+var plan9FlagMap = []struct {
+	flag FileFlags
+	mode os.FileMode
+}{
+	{FlagAppend, os.ModeAppend},
+	{FlagExclusive, os.ModeExclusive},
+}
+
+func getFileFlags(name string) (FileFlags, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return 0, getFlagsError(name, err)
+	}
+
+	var flags FileFlags
+
+	for _, m := range plan9FlagMap {
+		if fi.Mode()&m.mode != 0 {
+			flags |= m.flag
+		}
+	}
+
+	return flags, nil
+}
+
+func setFileFlags(name string, flags FileFlags) error {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	mode := fi.Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky | os.ModeAppend | os.ModeExclusive | os.ModeTemporary)
+	for _, m := range plan9FlagMap {
+		mode &^= m.mode
+		if flags&m.flag != 0 {
+			mode |= m.mode
+		}
+	}
+
+	err = os.Chmod(name, mode)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	return nil
+}
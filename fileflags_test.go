@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestFileFlagsString(t *testing.T) {
+	tests := []struct {
+		flags compat.FileFlags
+		want  string
+	}{
+		{0, ""},
+		{compat.FlagImmutable, "immutable"},
+		{compat.FlagNoDump | compat.FlagHidden, "nodump|hidden"},
+		{compat.FlagAppend | compat.FlagArchive | compat.FlagExclusive, "append|archive|exclusive"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.flags.String(); got != tt.want {
+			t.Errorf("FileFlags(%d).String(): got %q, want %q", uint32(tt.flags), got, tt.want)
+		}
+	}
+}
+
+func TestFileFlagsHas(t *testing.T) {
+	flags := compat.FlagHidden | compat.FlagSystem
+
+	if !flags.Has(compat.FlagHidden) {
+		t.Fatalf("%v.Has(%v): got false, want true", flags, compat.FlagHidden)
+	}
+
+	if !flags.Has(compat.FlagHidden | compat.FlagSystem) {
+		t.Fatalf("%v.Has(%v): got false, want true", flags, flags)
+	}
+
+	if flags.Has(compat.FlagHidden | compat.FlagArchive) {
+		t.Fatalf("%v.Has(%v): got true, want false", flags, compat.FlagHidden|compat.FlagArchive)
+	}
+}
+
+func TestFileFlagsSetAndGet(t *testing.T) {
+	// Flags that a file's owner can set without elevated privileges.
+	var flag compat.FileFlags
+
+	for _, f := range []compat.FileFlags{compat.FlagNoDump, compat.FlagArchive, compat.FlagExclusive} {
+		if compat.SupportedFileFlags().Has(f) {
+			flag = f
+
+			break
+		}
+	}
+
+	if flag == 0 {
+		skipf(t, "Skipping test: no user settable file flags supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flags, err := compat.GetFileFlags(name)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: file flags not supported on this filesystem: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if unsupported := flags &^ compat.SupportedFileFlags(); unsupported != 0 {
+		t.Fatalf("GetFileFlags(): got unsupported flags %v", unsupported)
+	}
+
+	err = compat.SetFileFlags(name, flags|flag)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.GetFileFlags(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != flags|flag {
+		t.Fatalf("GetFileFlags(): got %v, want %v", got, flags|flag)
+	}
+
+	err = compat.SetFileFlags(name, flags&^flag)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = compat.GetFileFlags(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != flags&^flag {
+		t.Fatalf("GetFileFlags(): got %v, want %v", got, flags&^flag)
+	}
+}
+
+func TestFileFlagsUnsupported(t *testing.T) {
+	unsupported := ^compat.SupportedFileFlags() & (compat.FlagExclusive<<1 - 1)
+	if unsupported == 0 {
+		skip(t, "Skipping test: all file flags are supported")
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.SetFileFlags(name, unsupported)
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("SetFileFlags(%v): got %v, want an unsupported error", unsupported, err)
+	}
+
+	var pe *os.PathError
+	if !errors.As(err, &pe) {
+		t.Fatalf("SetFileFlags(%v): got %T, want *os.PathError", unsupported, err)
+	}
+}
+
+func TestFileFlagsNoneSupported(t *testing.T) {
+	if compat.SupportedFileFlags() != 0 {
+		skipf(t, "Skipping test: file flags are supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.SetFileFlags(name, 0)
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("SetFileFlags(0): got %v, want an unsupported error", err)
+	}
+}
+
+func TestFileFlagsNotExist(t *testing.T) {
+	name := tempName(t)
+
+	_, err := compat.GetFileFlags(name)
+	if !errors.Is(err, os.ErrNotExist) && !compat.IsUnsupportedError(err) {
+		t.Fatalf("GetFileFlags(): got %v, want %v", err, os.ErrNotExist)
+	}
+}
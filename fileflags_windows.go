@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package compat
+
+import (
+	"golang.org/x/sys/windows"
+
+	"github.com/rasa/compat/golang"
+)
+
+const supportedFileFlags = FlagHidden | FlagSystem | FlagArchive
+
+var windowsFlagMap = []struct {
+	flag FileFlags
+	attr uint32
+}{
+	{FlagHidden, windows.FILE_ATTRIBUTE_HIDDEN},
+	{FlagSystem, windows.FILE_ATTRIBUTE_SYSTEM},
+	{FlagArchive, windows.FILE_ATTRIBUTE_ARCHIVE},
+}
+
+func getFileFlags(name string) (FileFlags, error) {
+	attrs, err := getFileAttributes(name)
+	if err != nil {
+		return 0, getFlagsError(name, err)
+	}
+
+	var flags FileFlags
+
+	for _, m := range windowsFlagMap {
+		if attrs&m.attr != 0 {
+			flags |= m.flag
+		}
+	}
+
+	return flags, nil
+}
+
+func setFileFlags(name string, flags FileFlags) error {
+	attrs, err := getFileAttributes(name)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	for _, m := range windowsFlagMap {
+		attrs &^= m.attr
+		if flags&m.flag != 0 {
+			attrs |= m.attr
+		}
+	}
+
+	if attrs == 0 {
+		attrs = windows.FILE_ATTRIBUTE_NORMAL
+	}
+
+	path16, err := windows.UTF16PtrFromString(golang.FixLongPath(name))
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	err = windows.SetFileAttributes(path16, attrs)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	return nil
+}
+
+func getFileAttributes(name string) (uint32, error) {
+	path16, err := windows.UTF16PtrFromString(golang.FixLongPath(name))
+	if err != nil {
+		return 0, err
+	}
+
+	return windows.GetFileAttributes(path16)
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"os"
+)
+
+// A FileKey uniquely identifies a file, and is comparable, so it can be used
+// as a map key, such as when detecting hard links, or directory loops.
+// It is returned by the [KeyOf] function.
+type FileKey struct {
+	Partition uint64 // unique disk partition ID
+	File      uint64 // unique file ID (on a specific partition)
+}
+
+// KeyOf returns the FileKey identifying the file described by fi.
+// If fi is a [FileInfo], the key is built from its PartitionID() and
+// FileID() values. Otherwise, it is built from fi's Sys() value, such as a
+// *syscall.Stat_t on Unix.
+// The boolean result is false if the file's identity is unknown.
+//
+// On Windows, the Sys() value of an os.FileInfo returned by os.Stat() does
+// not include the file's ID, so use this package's Stat function instead.
+func KeyOf(fi os.FileInfo) (FileKey, bool) {
+	var key FileKey
+
+	if cfi, ok := fi.(FileInfo); ok {
+		key = FileKey{Partition: cfi.PartitionID(), File: cfi.FileID()}
+	} else if fi != nil {
+		key = keyOfSys(fi.Sys())
+	}
+
+	return key, key != FileKey{}
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build plan9
+
+package compat
+
+import (
+	"syscall"
+)
+
+func keyOfSys(sys any) FileKey {
+	d, ok := sys.(*syscall.Dir)
+	if !ok || d == nil {
+		return FileKey{}
+	}
+
+	return FileKey{
+		Partition: uint64(d.Type)<<32 + uint64(d.Dev), //nolint:mnd
+		File:      d.Qid.Path,
+	}
+}
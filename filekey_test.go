@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestKeyOf(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, ok := compat.KeyOf(fi)
+	if !ok {
+		skipf(t, "Skipping test: file IDs not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	want := compat.FileKey{Partition: fi.PartitionID(), File: fi.FileID()}
+	if key != want {
+		t.Fatalf("KeyOf(): got %+v, want %+v", key, want)
+	}
+
+	if got, _ := compat.KeyOf(compat.NewSnapshot(fi)); got != key {
+		t.Fatalf("KeyOf(Snapshot): got %+v, want %+v", got, key)
+	}
+}
+
+func TestKeyOfOSFileInfo(t *testing.T) {
+	if compat.IsWindows {
+		skip(t, "Skipping test: os.Stat() doesn't return file IDs on Windows")
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	osfi, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, ok := compat.KeyOf(fi)
+	if !ok {
+		skipf(t, "Skipping test: file IDs not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	got, ok := compat.KeyOf(osfi)
+	if !ok {
+		t.Fatal("KeyOf(os.FileInfo): got false, want true")
+	}
+
+	if got != want {
+		t.Fatalf("KeyOf(os.FileInfo): got %+v, want %+v", got, want)
+	}
+}
+
+func TestKeyOfHardLinks(t *testing.T) {
+	if !supportsHardLinks(t) {
+		skip(t, "Skipping test: hard links not supported on "+runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link := filepath.Join(filepath.Dir(name), "link.txt")
+
+	err = compat.Link(name, link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[compat.FileKey]string{}
+
+	for _, path := range []string{name, link, other} {
+		fi, err := compat.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, ok := compat.KeyOf(fi)
+		if !ok {
+			skipf(t, "Skipping test: file IDs not supported on %v", runtime.GOOS)
+
+			return
+		}
+
+		if _, ok := seen[key]; !ok {
+			seen[key] = path
+		}
+	}
+
+	if got, want := len(seen), 2; got != want {
+		t.Fatalf("len(seen): got %v, want %v", got, want)
+	}
+
+	if got := seen[mustKeyOf(t, link)]; got != name {
+		t.Fatalf("seen[link]: got %v, want %v", got, name)
+	}
+}
+
+func TestKeyOfUnknown(t *testing.T) {
+	if _, ok := compat.KeyOf(nil); ok {
+		t.Fatal("KeyOf(nil): got true, want false")
+	}
+
+	var s compat.Snapshot
+	if _, ok := compat.KeyOf(&s); ok {
+		t.Fatal("KeyOf(Snapshot{}): got true, want false")
+	}
+
+	if compat.SameFile(&s, &s) {
+		t.Fatal("SameFile(Snapshot{}): got true, want false")
+	}
+
+	if compat.SamePartition(&s, &s) {
+		t.Fatal("SamePartition(Snapshot{}): got true, want false")
+	}
+}
+
+func TestSameFileSnapshot(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := compat.KeyOf(fi); !ok {
+		skipf(t, "Skipping test: file IDs not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	s := compat.NewSnapshot(fi)
+
+	if !compat.SameFile(fi, s) {
+		t.Fatal("SameFile(): got false, want true")
+	}
+
+	if !compat.SamePartition(fi, s) {
+		t.Fatal("SamePartition(): got false, want true")
+	}
+}
+
+func mustKeyOf(t *testing.T, name string) compat.FileKey {
+	t.Helper()
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := compat.KeyOf(fi)
+
+	return key
+}
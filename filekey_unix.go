@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(plan9 || windows)
+
+package compat
+
+import (
+	"syscall"
+)
+
+func keyOfSys(sys any) FileKey {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok || st == nil {
+		return FileKey{}
+	}
+
+	return FileKey{
+		Partition: uint64(st.Dev), //nolint:gosec,unconvert,nolintlint // intentional int32 → uint64 conversion
+		File:      uint64(st.Ino), //nolint:unconvert,nolintlint
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package compat
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+func keyOfSys(sys any) FileKey {
+	switch i := sys.(type) {
+	case *syscall.ByHandleFileInformation:
+		if i != nil {
+			return windowsKey(i.VolumeSerialNumber, i.FileIndexHigh, i.FileIndexLow)
+		}
+	case *windows.ByHandleFileInformation:
+		if i != nil {
+			return windowsKey(i.VolumeSerialNumber, i.FileIndexHigh, i.FileIndexLow)
+		}
+	}
+
+	// os.Stat() returns a *syscall.Win32FileAttributeData, which doesn't
+	// include the file's ID.
+	return FileKey{}
+}
+
+func windowsKey(volume, indexHigh, indexLow uint32) FileKey {
+	return FileKey{
+		Partition: uint64(volume),
+		File:      uint64(indexHigh)<<32 + uint64(indexLow), //nolint:mnd
+	}
+}
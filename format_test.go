@@ -74,6 +74,12 @@ func (fs *formatTest) FileID() uint64      { return 0 }
 func (fs *formatTest) Error() error        { return nil }
 func (fs *formatTest) String() string      { return "" }
 
+func (fs *formatTest) Attributes() compat.Attribute { return 0 }
+func (fs *formatTest) MountID() uint64              { return 0 }
+func (fs *formatTest) Errors() error                { return nil }
+
+func (fs *formatTest) Capabilities() *compat.FileCaps { return nil }
+
 var formatTests = []struct {
 	input        formatTest
 	wantFileInfo string
@@ -1,36 +1,79 @@
 // SPDX-FileCopyrightText: Copyright © 2025 Ross Smith II <ross@smithii.com>
 // SPDX-License-Identifier: MIT
 
-//go:build linux
+//go:build linux && !android
 
 package compat
 
 import (
 	"os"
 	"path/filepath"
-	"strconv"
+
+	"golang.org/x/sys/unix"
 )
 
+// fstat queries the descriptor itself, via statx(2) with AT_EMPTY_PATH, so it
+// works for unlinked and renamed files, pipes and sockets, and when /proc is
+// not mounted.
 func fstat(file *os.File) (FileInfo, error) {
 	if file == nil {
 		return nil, statError("", os.ErrInvalid)
 	}
 
-	fi, err := file.Stat()
+	if statxUnavailable.Load() {
+		return fstatOS(file)
+	}
+
+	conn, err := file.SyscallConn()
 	if err != nil {
 		return nil, statError(file.Name(), err)
 	}
 
-	fd := int(file.Fd())
+	var stx unix.Statx_t
 
-	link := "/proc/self/fd/" + strconv.Itoa(fd)
+	var serr error
 
-	path, err := os.Readlink(link)
+	err = conn.Control(func(fd uintptr) {
+		serr = ignoringEINTR(func() error {
+			return unix.Statx(int(fd), "", unix.AT_EMPTY_PATH|unix.AT_STATX_SYNC_AS_STAT, statxMask, &stx) //nolint:gosec // intentional uintptr → int conversion
+		})
+	})
 	if err != nil {
 		return nil, statError(file.Name(), err)
 	}
 
-	path = filepath.Clean(path)
+	if serr != nil {
+		if isStatxUnavailable(serr) {
+			statxUnavailable.Store(true)
+
+			return fstatOS(file)
+		}
+
+		return nil, statError(file.Name(), serr)
+	}
+
+	return fstatCapabilities(statFromStatx(&stx, filepath.Base(file.Name()), file.Name(), false), file), nil
+}
+
+// fstatOS is used when statx(2) is not available. The birth time is not
+// looked up, as it can only be retrieved via statx(2), so, as with a
+// filesystem without birth times, Errors() reports it as unsupported.
+func fstatOS(file *os.File) (FileInfo, error) {
+	fi, err := file.Stat()
+	if err != nil {
+		return nil, statError(file.Name(), err)
+	}
+
+	info, err := stat(fi, file.Name(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, ok := info.(*fileStat)
+	if ok {
+		fs.btimed = true
+		fs.setUnsupported(FieldBTime, "statx")
+	}
 
-	return stat(fi, path, false)
+	return fstatCapabilities(info, file), nil
 }
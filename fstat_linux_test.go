@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestFstatLinuxUnlinked(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+	defer f.Close()
+
+	want, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	err = os.Remove(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Fstat(f)
+	if err != nil {
+		fatalf(t, "Fstat: got %v, want nil", err)
+
+		return
+	}
+
+	if !compat.SameFile(fi, want) {
+		t.Errorf("Fstat: got %v:%v, want %v:%v", fi.PartitionID(), fi.FileID(), want.PartitionID(), want.FileID())
+	}
+
+	if got := fi.Links(); got != 0 {
+		t.Errorf("Links(): got %v, want 0", got)
+	}
+
+	if got := fi.Size(); got != want.Size() {
+		t.Errorf("Size(): got %v, want %v", got, want.Size())
+	}
+
+	if !fi.BTime().Equal(want.BTime()) {
+		t.Errorf("BTime(): got %v, want %v", fi.BTime(), want.BTime())
+	}
+
+	_ = fi.User()
+	_ = fi.Group()
+
+	if err := fi.Error(); err != nil {
+		t.Errorf("Error(): got %v, want nil", err)
+	}
+}
+
+func TestFstatLinuxRenamed(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+	defer f.Close()
+
+	renamed := filepath.Join(filepath.Dir(name), "renamed")
+
+	err = os.Rename(name, renamed)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	// create a different file with the original name.
+	err = os.WriteFile(name, nil, perm600)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Fstat(f)
+	if err != nil {
+		fatalf(t, "Fstat: got %v, want nil", err)
+
+		return
+	}
+
+	want, err := compat.Stat(renamed)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	if !compat.SameFile(fi, want) {
+		t.Errorf("Fstat: got %v:%v, want %v:%v", fi.PartitionID(), fi.FileID(), want.PartitionID(), want.FileID())
+	}
+
+	if !fi.BTime().Equal(want.BTime()) {
+		t.Errorf("BTime(): got %v, want %v", fi.BTime(), want.BTime())
+	}
+}
+
+func TestFstatLinuxPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+	defer r.Close()
+	defer w.Close()
+
+	fi, err := compat.Fstat(r)
+	if err != nil {
+		fatalf(t, "Fstat: got %v, want nil", err)
+
+		return
+	}
+
+	if fi.Mode()&os.ModeNamedPipe == 0 {
+		t.Errorf("Mode(): got %v, want a named pipe", fi.Mode())
+	}
+
+	// a pipe has no birth time, which Errors() reports, but Error() doesn't.
+	var fe *compat.FieldError
+	if err := fi.Errors(); !errors.As(err, &fe) || fe.Field != compat.FieldBTime || !compat.IsUnsupportedError(err) {
+		t.Errorf("Errors(): got %v, want an unsupported %v *FieldError", err, compat.FieldBTime)
+	}
+
+	if err := fi.Error(); err != nil {
+		t.Errorf("Error(): got %v, want nil", err)
+	}
+}
+
+func TestFstatLinuxFallback(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+	defer f.Close()
+
+	old := compat.StatxUnavailable.Load()
+	compat.StatxUnavailable.Store(true)
+
+	t.Cleanup(func() { compat.StatxUnavailable.Store(old) })
+
+	fi, err := compat.Fstat(f)
+	if err != nil {
+		fatalf(t, "Fstat: got %v, want nil", err)
+
+		return
+	}
+
+	osfi, err := f.Stat()
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	compareStatT(t, fi, osfi)
+
+	if !fi.BTime().IsZero() {
+		t.Errorf("BTime(): got %v, want zero", fi.BTime())
+	}
+
+	if err := fi.Error(); err != nil {
+		t.Errorf("Error(): got %v, want nil", err)
+	}
+
+	if err := fi.Errors(); !compat.IsUnsupportedError(err) {
+		t.Errorf("Errors(): got %v, want an unsupported error", err)
+	}
+}
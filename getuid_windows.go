@@ -43,12 +43,12 @@ func getuid() (int, error) {
 		return UnknownID, fmt.Errorf("failed to get token user: %w", err)
 	}
 
-	primaryDomainSID, err := getPrimaryDomainSID()
+	m, err := sidMapper()
 	if err != nil {
 		return UnknownID, fmt.Errorf("failed to get primary domain SID: %w", err)
 	}
 
-	uid, err := sidToPOSIXID(tokenUser.User.Sid, primaryDomainSID)
+	uid, err := sidToPOSIXID(tokenUser.User.Sid, m)
 	if err != nil {
 		return UnknownID, fmt.Errorf("failed to convert SID to POSIX ID: %w", err)
 	}
@@ -82,7 +82,7 @@ func getgid() (int, error) {
 	}
 	defer token.Close()
 
-	primaryDomainSID, err := getPrimaryDomainSID()
+	m, err := sidMapper()
 	if err != nil {
 		return UnknownID, fmt.Errorf("failed to get primary domain SID: %w", err)
 	}
@@ -92,7 +92,7 @@ func getgid() (int, error) {
 		return UnknownID, fmt.Errorf("failed to get primary group SID: %w", err)
 	}
 
-	gid, err := sidToPOSIXID(groupSID, primaryDomainSID)
+	gid, err := sidToPOSIXID(groupSID, m)
 	if err != nil {
 		return UnknownID, fmt.Errorf("failed to convert SID to POSIX ID: %w", err)
 	}
@@ -60,6 +60,34 @@ func GetOptions() []Option {
 		opts = append(opts, WithSetSymlinkOwner(options.setSymlinkOwner))
 	}
 
+	if options.hashCacheSet {
+		opts = append(opts, WithHashCache(options.hashCache))
+	}
+
+	if options.timeGranularity != optionDefaults.timeGranularity {
+		opts = append(opts, WithTimeGranularity(options.timeGranularity))
+	}
+
+	if options.dotRename != optionDefaults.dotRename {
+		opts = append(opts, WithDotRename(options.dotRename))
+	}
+
+	if options.resolverSet {
+		opts = append(opts, WithResolver(options.resolver))
+	}
+
+	if options.durable != optionDefaults.durable {
+		opts = append(opts, WithDurability(options.durable))
+	}
+
+	if options.progressInterval != optionDefaults.progressInterval {
+		opts = append(opts, WithProgressInterval(options.progressInterval))
+	}
+
+	if options.tempPattern != optionDefaults.tempPattern {
+		opts = append(opts, WithTempPattern(options.tempPattern))
+	}
+
 	return opts
 }
 
@@ -71,6 +99,10 @@ func SetOptions(opts ...Option) {
 		fn(&options)
 	}
 
+	// The context, and progress function, are per-call only.
+	options.ctx = nil
+	options.progress = nil
+
 	optionsPtr.Store(&options)
 	optionsMux.Unlock()
 }
@@ -96,6 +128,13 @@ func (o Options) String() string {
 	fmt.Fprintf(&builder, "readOnlyMode:    %v\n", o.readOnlyMode)
 	fmt.Fprintf(&builder, "retrySeconds:    %v\n", o.retrySeconds)
 	fmt.Fprintf(&builder, "setSymlinkOwner: %v\n", o.setSymlinkOwner)
+	fmt.Fprintf(&builder, "timeGranularity: %v\n", o.timeGranularity)
+	fmt.Fprintf(&builder, "hashCache:       %T\n", o.hashCache)
+	fmt.Fprintf(&builder, "dotRename:       %v\n", o.dotRename)
+	fmt.Fprintf(&builder, "resolver:        %T\n", o.resolver)
+	fmt.Fprintf(&builder, "durable:         %v\n", o.durable)
+	fmt.Fprintf(&builder, "progressInterval: %v\n", o.progressInterval)
+	fmt.Fprintf(&builder, "tempPattern:     %v\n", o.tempPattern)
 
 	return builder.String()
 }
@@ -4,17 +4,19 @@
 package compat_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rasa/compat"
 )
 
 func TestGetOptions(t *testing.T) {
-	opts := make([]compat.Option, 0, 9)
+	opts := make([]compat.Option, 0, 11)
 	opts = append(opts, compat.WithNonAtomicReplace(true))
 	opts = append(opts, compat.WithAtomicity(true))
 	opts = append(opts, compat.WithDefaultFileMode(perm777))
@@ -24,13 +26,22 @@ func TestGetOptions(t *testing.T) {
 	opts = append(opts, compat.WithReadOnlyMode(compat.ReadOnlyModeSet))
 	opts = append(opts, compat.WithRetrySeconds(1))
 	opts = append(opts, compat.WithSetSymlinkOwner(true))
+	opts = append(opts, compat.WithTimeGranularity(2*time.Second))
+	opts = append(opts, compat.WithHashCache(compat.NewHashCache()))
+	opts = append(opts, compat.WithDotRename(true))
+	opts = append(opts, compat.WithResolver(compat.OSResolver{}))
+	opts = append(opts, compat.WithDurability(true))
+	opts = append(opts, compat.WithProgressInterval(1024))
+	opts = append(opts, compat.WithTempPattern("~*.tmp"))
 
 	compat.SetOptions(opts...)
 
 	o := compat.GetOptions()
 	got := len(o)
 
-	want := reflect.TypeFor[compat.Options]().NumField()
+	// ctx and progress are per-call only, so they aren't returned, and
+	// hashCacheSet and resolverSet are returned with hashCache and resolver.
+	want := reflect.TypeFor[compat.Options]().NumField() - 4
 	if got != want {
 		t.Fatalf("got %d, want %d", got, want)
 	}
@@ -50,6 +61,13 @@ keepFileMode:    true
 readOnlyMode:    1
 retrySeconds:    1
 setSymlinkOwner: true
+timeGranularity: 2s
+hashCache:       *compat.MemHashCache
+dotRename:       true
+resolver:        compat.OSResolver
+durable:         true
+progressInterval: 1024
+tempPattern:     ~*.tmp
 `
 	flags := fmt.Sprintf("0x%x", os.O_CREATE)
 
@@ -60,7 +78,7 @@ setSymlinkOwner: true
 }
 
 func TestBuildOptions2(t *testing.T) {
-	opts := make([]compat.Option, 0, 9)
+	opts := make([]compat.Option, 0, 11)
 	opts = append(opts, compat.WithNonAtomicReplace(true))
 	opts = append(opts, compat.WithAtomicity(true))
 	opts = append(opts, compat.WithDefaultFileMode(perm777))
@@ -70,6 +88,13 @@ func TestBuildOptions2(t *testing.T) {
 	opts = append(opts, compat.WithReadOnlyMode(compat.ReadOnlyModeSet))
 	opts = append(opts, compat.WithRetrySeconds(1))
 	opts = append(opts, compat.WithSetSymlinkOwner(true))
+	opts = append(opts, compat.WithTimeGranularity(2*time.Second))
+	opts = append(opts, compat.WithHashCache(compat.NewHashCache()))
+	opts = append(opts, compat.WithDotRename(true))
+	opts = append(opts, compat.WithResolver(compat.OSResolver{}))
+	opts = append(opts, compat.WithDurability(true))
+	opts = append(opts, compat.WithProgressInterval(1024))
+	opts = append(opts, compat.WithTempPattern("~*.tmp"))
 	compat.SetOptions(opts...)
 	fopts := compat.BuildOptions(opts...)
 	got := fopts.String()
@@ -82,6 +107,13 @@ keepFileMode:    true
 readOnlyMode:    1
 retrySeconds:    1
 setSymlinkOwner: true
+timeGranularity: 2s
+hashCache:       *compat.MemHashCache
+dotRename:       true
+resolver:        compat.OSResolver
+durable:         true
+progressInterval: 1024
+tempPattern:     ~*.tmp
 `
 	flags := fmt.Sprintf("0x%x", os.O_CREATE)
 
@@ -90,3 +122,71 @@ setSymlinkOwner: true
 		t.Fatalf("got:\n---\n%v\n---\nwant:\n---\n%v\n---\n", got, want)
 	}
 }
+
+func TestSetOptionsPerCallOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	called := false
+
+	compat.SetOptions(compat.WithContext(ctx), compat.WithProgress(func(int64) { called = true }))
+
+	err := compat.WriteFile(tempName(t), helloBytes, perm600)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+
+	if called {
+		t.Fatal("the progress function passed to SetOptions was called")
+	}
+}
+
+// mapHashCache is a HashCache whose dynamic type isn't comparable.
+type mapHashCache struct {
+	hashes map[compat.HashKey][]byte
+}
+
+func (c mapHashCache) Get(key compat.HashKey) ([]byte, bool) {
+	sum, ok := c.hashes[key]
+
+	return sum, ok
+}
+
+func (c mapHashCache) Put(key compat.HashKey, sum []byte) {
+	c.hashes[key] = sum
+}
+
+func TestGetOptionsUncomparableHashCache(t *testing.T) {
+	compat.SetOptions(compat.WithHashCache(mapHashCache{hashes: map[compat.HashKey][]byte{}}))
+	t.Cleanup(func() { compat.SetOptions(compat.WithHashCache(nil)) })
+
+	_ = compat.GetOptions()
+}
+
+// mapResolver is a Resolver whose dynamic type isn't comparable.
+type mapResolver struct {
+	names map[int]string
+}
+
+func (r mapResolver) UserName(uid int) (string, error) {
+	return r.names[uid], nil
+}
+
+func (r mapResolver) GroupName(gid int) (string, error) {
+	return r.names[gid], nil
+}
+
+func (r mapResolver) UserID(_ string) (int, error) {
+	return 0, nil
+}
+
+func (r mapResolver) GroupID(_ string) (int, error) {
+	return 0, nil
+}
+
+func TestGetOptionsUncomparableResolver(t *testing.T) {
+	compat.SetOptions(compat.WithResolver(mapResolver{names: map[int]string{}}))
+	t.Cleanup(func() { compat.SetOptions(compat.WithResolver(nil)) })
+
+	_ = compat.GetOptions()
+}
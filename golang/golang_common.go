@@ -28,6 +28,9 @@ var getwdCache struct {
 // Snippet: https://github.com/golang/go/blob/ac803b59/src/os/tempfile.go#L22-L24
 
 func nextRandom() string {
+	if fn := random.Load(); fn != nil { // compat: added
+		return (*fn)() // compat: added
+	} // compat: added
 	return Uitoa(uint(uint32(runtime_rand())))
 }
 
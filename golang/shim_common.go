@@ -5,6 +5,8 @@ package golang
 
 import (
 	"os"
+	"strconv"
+	"sync/atomic"
 	_ "unsafe" // for go:linkname
 )
 
@@ -49,3 +51,54 @@ type (
 func runtime_rand() uint64
 
 var PrefixAndSuffix = prefixAndSuffix
+
+// random, if set, generates the random part of the names CreateTemp and
+// MkdirTemp create.
+var random atomic.Pointer[func() string]
+
+// NextRandom returns the random part of a name created by CreateTemp and
+// MkdirTemp, which, by default, is a random uint32 in decimal.
+func NextRandom() string {
+	return nextRandom()
+}
+
+// SetRandom replaces the function that generates the random part of the
+// names CreateTemp and MkdirTemp create, so tests can use deterministic
+// names. If fn is nil, the default is restored. It returns a function that
+// restores the previous function.
+// Like the default, fn must return one or more decimal digits, as compat's
+// CleanupStaleTemps only removes names with a decimal random part. If it
+// returns anything else, CreateTemp and MkdirTemp panic.
+func SetRandom(fn func() string) (restore func()) {
+	var prev *func() string
+	if fn == nil {
+		prev = random.Swap(nil)
+	} else {
+		decimal := func() string {
+			s := fn()
+			if !isDecimal(s) {
+				panic("golang: SetRandom function returned " + strconv.Quote(s) + ", want decimal digits")
+			}
+
+			return s
+		}
+		prev = random.Swap(&decimal)
+	}
+
+	return func() { random.Store(prev) }
+}
+
+// isDecimal returns true if s is one or more decimal digits.
+func isDecimal(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
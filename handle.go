@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+)
+
+// A Handle is an opaque, persistent, file handle, returned by the
+// [FileHandle] function. Unlike a FileID(), a Handle isn't reused after the
+// file is deleted, and isn't changed by renaming the file, so it can
+// identify a file over long periods of time.
+//
+// A Handle can be serialized using its MarshalBinary function, or as JSON,
+// and can only be used on the machine that created it, while the
+// filesystem remains mounted.
+type Handle struct {
+	Type    int32  // filesystem specific handle type
+	Data    []byte // opaque filesystem specific handle data
+	MountID uint64 // mount ID of the filesystem, as returned by FileInfo's MountID()
+}
+
+const (
+	handleVersion    = 1
+	handleHeaderSize = 1 + 8 + 4 // version, mount ID, type
+)
+
+var errInvalidHandle = errors.New("invalid handle")
+
+// FileHandle returns a persistent [Handle] for the named file.
+// If the file is a symbolic link, the handle describes the link's target.
+// The function is supported on Linux only. On other operating systems, or if
+// the filesystem doesn't support file handles, the error wraps an
+// *UnsupportedError, or EOPNOTSUPP.
+// If there is an error, it will be of type [*PathError].
+func FileHandle(name string) (Handle, error) {
+	return fileHandle(name)
+}
+
+// OpenByHandle opens the file described by h, using the flag (O_RDONLY etc.)
+// parameter. The file doesn't need to be accessible by name, so opening it
+// requires the CAP_DAC_READ_SEARCH capability, typically root.
+// The function is supported on Linux only.
+// If there is an error, it will be of type [*PathError].
+func OpenByHandle(h Handle, flag int) (*os.File, error) {
+	return openByHandle(h, flag)
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+func (h Handle) MarshalBinary() ([]byte, error) {
+	data := make([]byte, handleHeaderSize, handleHeaderSize+len(h.Data))
+	data[0] = handleVersion
+	binary.LittleEndian.PutUint64(data[1:], h.MountID)
+	binary.LittleEndian.PutUint32(data[9:], uint32(h.Type)) //nolint:gosec // intentional int32 → uint32 conversion
+
+	return append(data, h.Data...), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+func (h *Handle) UnmarshalBinary(data []byte) error {
+	if len(data) <= handleHeaderSize || data[0] != handleVersion {
+		return errInvalidHandle
+	}
+
+	h.MountID = binary.LittleEndian.Uint64(data[1:])
+	h.Type = int32(binary.LittleEndian.Uint32(data[9:])) //nolint:gosec // intentional uint32 → int32 conversion
+	h.Data = append([]byte(nil), data[handleHeaderSize:]...)
+
+	return nil
+}
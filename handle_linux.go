@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const mountInfoPath = "/proc/self/mountinfo"
+
+func fileHandle(name string) (Handle, error) {
+	fh, mountID, err := unix.NameToHandleAt(unix.AT_FDCWD, name, unix.AT_SYMLINK_FOLLOW)
+	if err != nil {
+		return Handle{}, fileHandleError(name, err)
+	}
+
+	return Handle{
+		Type:    fh.Type(),
+		Data:    fh.Bytes(),
+		MountID: uint64(mountID), //nolint:gosec // intentional int → uint64 conversion
+	}, nil
+}
+
+func openByHandle(h Handle, flag int) (*os.File, error) {
+	mountPoint, err := mountPointOf(h.MountID)
+	if err != nil {
+		return nil, openByHandleError(mountInfoPath, err)
+	}
+
+	mountFD, err := unix.Open(mountPoint, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, openByHandleError(mountPoint, err)
+	}
+	defer unix.Close(mountFD) //nolint:errcheck
+
+	fd, err := unix.OpenByHandleAt(mountFD, unix.NewFileHandle(h.Type, h.Data), flag|unix.O_CLOEXEC)
+	if err != nil {
+		return nil, openByHandleError(mountPoint, err)
+	}
+
+	// The file may have been renamed, so ask the kernel for its current name.
+	name, err := os.Readlink("/proc/self/fd/" + strconv.Itoa(fd))
+	if err != nil {
+		name = mountPoint
+	}
+
+	return os.NewFile(uintptr(fd), name), nil
+}
+
+// mountPointOf returns the mount point of the filesystem with the mount ID
+// mountID, from /proc/self/mountinfo.
+// See https://man7.org/linux/man-pages/man5/proc_pid_mountinfo.5.html
+func mountPointOf(mountID uint64) (string, error) {
+	f, err := os.Open(mountInfoPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck
+
+	id := strconv.FormatUint(mountID, 10)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 4 && fields[0] == id {
+			return unescapeMountInfo(fields[4]), nil
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return "", err
+	}
+
+	return "", os.ErrNotExist
+}
+
+// unescapeMountInfo replaces the octal escapes (\040 etc.) used for spaces,
+// tabs, newlines, and backslashes in mountinfo paths.
+func unescapeMountInfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var builder strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) && isOctal(s[i+1:i+4]) {
+			n, _ := strconv.ParseUint(s[i+1:i+4], 8, 8)
+			builder.WriteByte(byte(n))
+
+			i += 3
+
+			continue
+		}
+
+		builder.WriteByte(s[i])
+	}
+
+	return builder.String()
+}
+
+func isOctal(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '7' {
+			return false
+		}
+	}
+
+	return true
+}
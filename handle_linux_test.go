@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat_test
+
+import (
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestUnescapeMountInfo(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"/", "/"},
+		{`/mnt/my\040disk`, "/mnt/my disk"},
+		{`/a\011b\012c\134d`, "/a\tb\nc\\d"},
+		{`/trailing\04`, `/trailing\04`},
+		{`/not\08x`, `/not\08x`},
+	}
+
+	for _, tt := range tests {
+		if got := compat.UnescapeMountInfo(tt.in); got != tt.want {
+			t.Errorf("UnescapeMountInfo(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !linux || android
+
+package compat
+
+import (
+	"os"
+)
+
+func fileHandle(name string) (Handle, error) {
+	return Handle{}, fileHandleError(name, &UnsupportedError{Op: "FileHandle"})
+}
+
+func openByHandle(_ Handle, _ int) (*os.File, error) {
+	return nil, openByHandleError("", &UnsupportedError{Op: "OpenByHandle"})
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestFileHandle(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := compat.FileHandle(name)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: FileHandle() not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(h.Data) == 0 {
+		t.Fatal("FileHandle(): got empty handle data")
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.MountID() != 0 && fi.MountID() != h.MountID {
+		t.Fatalf("MountID: got %v, want %v", h.MountID, fi.MountID())
+	}
+
+	renamed := name + ".renamed"
+
+	err = os.Rename(name, renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h2, err := compat.FileHandle(renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h2.Type != h.Type || !bytes.Equal(h2.Data, h.Data) || h2.MountID != h.MountID {
+		t.Fatalf("FileHandle() after rename: got %+v, want %+v", h2, h)
+	}
+}
+
+func TestFileHandleUnsupported(t *testing.T) {
+	if runtime.GOOS == "linux" {
+		skip(t, "Skipping test: FileHandle() is supported on linux")
+
+		return
+	}
+
+	_, err := compat.FileHandle(tempName(t))
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("FileHandle(): got %v, want %v", err, errors.ErrUnsupported)
+	}
+
+	_, err = compat.OpenByHandle(compat.Handle{}, os.O_RDONLY)
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("OpenByHandle(): got %v, want %v", err, errors.ErrUnsupported)
+	}
+}
+
+func TestOpenByHandle(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := compat.FileHandle(name)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: FileHandle() not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renamed := name + ".renamed"
+
+	err = os.Rename(name, renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := compat.OpenByHandle(h, os.O_RDONLY)
+	if errors.Is(err, os.ErrPermission) {
+		skipf(t, "Skipping test: OpenByHandle() requires CAP_DAC_READ_SEARCH: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	if got := f.Name(); got != renamed {
+		t.Errorf("Name(): got %v, want %v", got, renamed)
+	}
+
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("ReadAll(): got %q, want %q", got, helloBytes)
+	}
+}
+
+func TestHandleMarshalBinary(t *testing.T) {
+	want := compat.Handle{Type: -2, Data: []byte{1, 2, 3, 4, 5, 6, 7, 8}, MountID: 1 << 40}
+
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got compat.Handle
+
+	err = got.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Type != want.Type || !bytes.Equal(got.Data, want.Data) || got.MountID != want.MountID {
+		t.Fatalf("UnmarshalBinary(): got %+v, want %+v", got, want)
+	}
+
+	for _, data := range [][]byte{nil, data[:13], append([]byte{0}, data[1:]...)} {
+		err = got.UnmarshalBinary(data)
+		if err == nil {
+			t.Fatalf("UnmarshalBinary(%v): got nil, want an error", data)
+		}
+	}
+}
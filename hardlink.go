@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"sync"
+	"time"
+)
+
+// A HardLinkTracker detects hard links, so archivers, such as tar and cpio,
+// can store a file's content once, and store every other link to the file as
+// a reference to the first one.
+//
+// To save memory, only files with more than one link are recorded, and a
+// file is forgotten once all its links have been seen.
+//
+// File IDs can be reused after a file is deleted, and on Windows, the 64-bit
+// file index isn't guaranteed to be unique on ReFS volumes. So a file is only
+// reported as a link if its size and modification time also match the first
+// occurrence.
+//
+// The zero value is ready to use. A HardLinkTracker is safe for concurrent
+// use by multiple goroutines.
+type HardLinkTracker struct {
+	mux   sync.Mutex
+	files map[FileKey]*hardLink
+}
+
+type hardLink struct {
+	path  string
+	size  int64
+	mtime time.Time
+	left  uint // links not yet seen
+}
+
+// NewHardLinkTracker returns a new HardLinkTracker.
+func NewHardLinkTracker() *HardLinkTracker {
+	return &HardLinkTracker{}
+}
+
+// Add records the file fi, found at path. If fi is a link to a file that
+// was previously added, Add returns the path that file was added with, and
+// true. Otherwise, fi is the first occurrence of the file, and Add returns
+// "", and false.
+//
+// Directories, and files whose identity or link count is unknown, are never
+// reported as links.
+func (t *HardLinkTracker) Add(path string, fi FileInfo) (string, bool) {
+	if fi.IsDir() || fi.Links() <= 1 {
+		return "", false
+	}
+
+	key, ok := KeyOf(fi)
+	if !ok {
+		return "", false
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	link, ok := t.files[key]
+	if ok && link.size == fi.Size() && link.mtime.Equal(fi.ModTime()) {
+		link.left--
+		if link.left == 0 {
+			delete(t.files, key)
+		}
+
+		return link.path, true
+	}
+
+	if t.files == nil {
+		t.files = make(map[FileKey]*hardLink)
+	}
+
+	// Either a new file, or the file ID was reused.
+	t.files[key] = &hardLink{
+		path:  path,
+		size:  fi.Size(),
+		mtime: fi.ModTime(),
+		left:  fi.Links() - 1,
+	}
+
+	return "", false
+}
+
+// Len returns the number of files with links that have not been seen yet.
+func (t *HardLinkTracker) Len() int {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	return len(t.files)
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+func TestHardLinkTracker(t *testing.T) {
+	if !supportsHardLinks(t) {
+		skip(t, "Skipping test: hard links not supported on "+runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Dir(name)
+	link1 := filepath.Join(dir, "link1.txt")
+	link2 := filepath.Join(dir, "link2.txt")
+
+	for _, link := range []string{link1, link2} {
+		err = compat.Link(name, link)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	other, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path   string
+		want   string
+		isLink bool
+		len    int
+	}{
+		{name, "", false, 1},
+		{other, "", false, 1},
+		{link1, name, true, 1},
+		{link2, name, true, 0},
+	}
+
+	tracker := compat.NewHardLinkTracker()
+
+	for _, tt := range tests {
+		fi, err := compat.Stat(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := compat.KeyOf(fi); !ok {
+			skipf(t, "Skipping test: file IDs not supported on %v", runtime.GOOS)
+
+			return
+		}
+
+		got, isLink := tracker.Add(tt.path, fi)
+		if got != tt.want || isLink != tt.isLink {
+			t.Fatalf("Add(%v): got %q, %v, want %q, %v", tt.path, got, isLink, tt.want, tt.isLink)
+		}
+
+		if got := tracker.Len(); got != tt.len {
+			t.Fatalf("Len(): got %v, want %v", got, tt.len)
+		}
+	}
+}
+
+func TestHardLinkTrackerReusedID(t *testing.T) {
+	first := snapshotFromJSON(t, `{"name":"a","size":5,"mtime":"2026-01-02T03:04:05Z",`+
+		`"links":2,"partitionID":1,"fileID":2}`)
+	reused := snapshotFromJSON(t, `{"name":"b","size":6,"mtime":"2026-01-03T03:04:05Z",`+
+		`"links":2,"partitionID":1,"fileID":2}`)
+	link := snapshotFromJSON(t, `{"name":"c","size":6,"mtime":"2026-01-03T03:04:05Z",`+
+		`"links":2,"partitionID":1,"fileID":2}`)
+
+	var tracker compat.HardLinkTracker
+
+	if _, isLink := tracker.Add("a", first); isLink {
+		t.Fatal("Add(a): got true, want false")
+	}
+
+	if got, isLink := tracker.Add("b", reused); isLink {
+		t.Fatalf("Add(b): got %q, true, want false", got)
+	}
+
+	if got, isLink := tracker.Add("c", link); !isLink || got != "b" {
+		t.Fatalf("Add(c): got %q, %v, want %q, true", got, isLink, "b")
+	}
+}
+
+func TestHardLinkTrackerIgnored(t *testing.T) {
+	tests := []string{
+		`{"name":"single","links":1,"partitionID":1,"fileID":2}`,
+		`{"name":"dir","mode":2147484141,"links":3,"partitionID":1,"fileID":3}`,
+		`{"name":"unknown","links":2}`,
+	}
+
+	var tracker compat.HardLinkTracker
+
+	for _, data := range tests {
+		s := snapshotFromJSON(t, data)
+
+		for range 2 {
+			if got, isLink := tracker.Add(s.Name(), s); isLink {
+				t.Fatalf("Add(%v): got %q, true, want false", s.Name(), got)
+			}
+		}
+	}
+
+	if got := tracker.Len(); got != 0 {
+		t.Fatalf("Len(): got %v, want 0", got)
+	}
+}
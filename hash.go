@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/OneOfOne/xxhash"
+)
+
+// HashAlgorithm names a hash algorithm used by the [HashFile] function.
+type HashAlgorithm string
+
+const (
+	// HashXXH64 is the 64-bit xxHash algorithm. It's not cryptographically
+	// secure, but it's much faster than SHA-256.
+	HashXXH64 HashAlgorithm = "xxh64"
+	// HashSHA256 is the SHA-256 algorithm.
+	HashSHA256 HashAlgorithm = "sha256"
+)
+
+// ErrUnknownHash is returned by HashFile if the hash algorithm hasn't been
+// registered.
+var ErrUnknownHash = errors.New("unknown hash algorithm")
+
+// A file modified within racyWindow of being hashed may be modified again
+// without changing its modification time, so its hash isn't cached.
+// 2 seconds is the FAT filesystem's mtime granularity.
+const racyWindow = 2 * time.Second
+
+var (
+	hashMux   sync.RWMutex
+	hashFuncs = map[HashAlgorithm]func() hash.Hash{
+		HashXXH64:  xxhash.NewHash64,
+		HashSHA256: sha256.New,
+	}
+)
+
+// RegisterHash registers a function that returns a new instance of the hash
+// algorithm algo, so it can be used by HashFile. It replaces any function
+// previously registered for algo.
+func RegisterHash(algo HashAlgorithm, fn func() hash.Hash) {
+	hashMux.Lock()
+	defer hashMux.Unlock()
+
+	hashFuncs[algo] = fn
+}
+
+// HashFile returns the hash of the contents of the named file, using the
+// hash algorithm algo.
+//
+// If the WithHashCache option is used, the hash is looked up in, and saved
+// to, the cache, keyed by the file's identity, size, modification time, and
+// metadata change time, so an unchanged file is only read once.
+// Files whose identity is unknown, or that were modified very recently, are
+// not cached.
+// If there is an error, it will be of type [*PathError].
+func HashFile(name string, algo HashAlgorithm, opts ...Option) ([]byte, error) {
+	fopts := buildOptions(opts...)
+
+	hashMux.RLock()
+	newHash, ok := hashFuncs[algo]
+	hashMux.RUnlock()
+
+	if !ok {
+		return nil, hashError(name, fmt.Errorf("%w: %q", ErrUnknownHash, algo))
+	}
+
+	cache := fopts.hashCache
+
+	var key HashKey
+
+	cacheable := false
+
+	if cache != nil {
+		fi, err := Stat(name)
+		if err != nil {
+			return nil, err
+		}
+
+		key, cacheable = hashKeyOf(fi, algo)
+		if cacheable {
+			sum, ok := cache.Get(key)
+			if ok {
+				return sum, nil
+			}
+		}
+	}
+
+	sum, err := hashFile(name, newHash())
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable && time.Since(time.Unix(0, key.MTime)) >= racyWindow {
+		// Only cache the hash if the file didn't change while it was read.
+		fi, err := Stat(name)
+		if err == nil {
+			if key2, ok := hashKeyOf(fi, algo); ok && key2 == key {
+				cache.Put(key, sum)
+			}
+		}
+	}
+
+	return sum, nil
+}
+
+func hashFile(name string, h hash.Hash) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// A HashKey identifies a file's contents, as of the time it was hashed, and
+// is the key used by a [HashCache].
+type HashKey struct {
+	File      FileKey       // the file's identity
+	Algorithm HashAlgorithm // the hash algorithm
+	Size      int64         // the file's size
+	MTime     int64         // the file's modification time, in nanoseconds since the Unix epoch
+	CTime     int64         // the file's metadata change time, in nanoseconds since the Unix epoch, or 0 if unsupported
+}
+
+func hashKeyOf(fi FileInfo, algo HashAlgorithm) (HashKey, bool) {
+	fileKey, ok := KeyOf(fi)
+	if !ok || !fi.Mode().IsRegular() {
+		return HashKey{}, false
+	}
+
+	key := HashKey{
+		File:      fileKey,
+		Algorithm: algo,
+		Size:      fi.Size(),
+		MTime:     fi.ModTime().UnixNano(),
+	}
+
+	if ctime := fi.CTime(); !ctime.IsZero() {
+		key.CTime = ctime.UnixNano()
+	}
+
+	return key, true
+}
+
+// A HashCache stores the hashes returned by HashFile, and is set using the
+// WithHashCache option. Implementations must be safe for concurrent use by
+// multiple goroutines.
+type HashCache interface {
+	// Get returns the hash stored for key, and true, or nil, and false, if
+	// none is stored.
+	Get(key HashKey) ([]byte, bool)
+	// Put stores the hash sum for key.
+	Put(key HashKey, sum []byte)
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+)
+
+// A MemHashCache is an in-memory [HashCache], that can be persisted using its
+// Save and Load functions. The zero value is ready to use.
+// A MemHashCache is safe for concurrent use by multiple goroutines.
+type MemHashCache struct {
+	mux    sync.RWMutex
+	hashes map[HashKey][]byte
+}
+
+// NewHashCache returns a new, empty, MemHashCache.
+func NewHashCache() *MemHashCache {
+	return &MemHashCache{}
+}
+
+// Get implements [HashCache].
+func (c *MemHashCache) Get(key HashKey) ([]byte, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	sum, ok := c.hashes[key]
+
+	// return a copy, so the caller can't change the cached sum.
+	return bytes.Clone(sum), ok
+}
+
+// Put implements [HashCache].
+func (c *MemHashCache) Put(key HashKey, sum []byte) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.hashes == nil {
+		c.hashes = make(map[HashKey][]byte)
+	}
+
+	c.hashes[key] = bytes.Clone(sum)
+}
+
+// Len returns the number of hashes in the cache.
+func (c *MemHashCache) Len() int {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	return len(c.hashes)
+}
+
+// hashEntry is the JSON representation of a cached hash.
+type hashEntry struct {
+	Partition uint64        `json:"partition"`
+	File      uint64        `json:"file"`
+	Algorithm HashAlgorithm `json:"algorithm"`
+	Size      int64         `json:"size"`
+	MTime     int64         `json:"mtime"`
+	CTime     int64         `json:"ctime"`
+	Sum       []byte        `json:"sum"`
+}
+
+// Save writes the cache's hashes to w, as JSON, in a stable order.
+func (c *MemHashCache) Save(w io.Writer) error {
+	c.mux.RLock()
+
+	entries := make([]hashEntry, 0, len(c.hashes))
+	for key, sum := range c.hashes {
+		entries = append(entries, hashEntry{
+			Partition: key.File.Partition,
+			File:      key.File.File,
+			Algorithm: key.Algorithm,
+			Size:      key.Size,
+			MTime:     key.MTime,
+			CTime:     key.CTime,
+			Sum:       sum,
+		})
+	}
+
+	c.mux.RUnlock()
+
+	slices.SortFunc(entries, func(a, b hashEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Partition, b.Partition),
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Algorithm, b.Algorithm),
+			cmp.Compare(a.MTime, b.MTime),
+			cmp.Compare(a.CTime, b.CTime),
+			cmp.Compare(a.Size, b.Size),
+		)
+	})
+
+	return json.NewEncoder(w).Encode(entries)
+}
+
+// Load reads hashes written by Save from r, and adds them to the cache.
+func (c *MemHashCache) Load(r io.Reader) error {
+	var entries []hashEntry
+
+	err := json.NewDecoder(r).Decode(&entries)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		c.Put(HashKey{
+			File:      FileKey{Partition: e.Partition, File: e.File},
+			Algorithm: e.Algorithm,
+			Size:      e.Size,
+			MTime:     e.MTime,
+			CTime:     e.CTime,
+		}, e.Sum)
+	}
+
+	return nil
+}
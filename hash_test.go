@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"hash/fnv"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/rasa/compat"
+)
+
+func TestHashFile(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		algo compat.HashAlgorithm
+		want string
+	}{
+		{compat.HashXXH64, "26c7827d889f6da3"},
+		{compat.HashSHA256, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+
+	for _, tt := range tests {
+		sum, err := compat.HashFile(name, tt.algo, compat.WithHashCache(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := hex.EncodeToString(sum); got != tt.want {
+			t.Errorf("HashFile(%v): got %v, want %v", tt.algo, got, tt.want)
+		}
+	}
+}
+
+func TestHashFileErrors(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.HashFile(name, "unknown")
+	if !errors.Is(err, compat.ErrUnknownHash) {
+		t.Fatalf("HashFile(): got %v, want %v", err, compat.ErrUnknownHash)
+	}
+
+	_, err = compat.HashFile(tempName(t), compat.HashXXH64, compat.WithHashCache(compat.NewHashCache()))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("HashFile(): got %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestRegisterHash(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const fnv64a compat.HashAlgorithm = "fnv64a"
+
+	compat.RegisterHash(fnv64a, func() hash.Hash { return fnv.New64a() })
+
+	sum, err := compat.HashFile(name, fnv64a, compat.WithHashCache(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(helloBytes)
+
+	if want := h.Sum(nil); !bytes.Equal(sum, want) {
+		t.Fatalf("HashFile(): got %x, want %x", sum, want)
+	}
+}
+
+func TestHashFileCache(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Files modified in the last couple of seconds aren't cached.
+	err = compat.Chtimes(name, compat.FileTimes{MTime: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileKey, ok := compat.KeyOf(fi)
+	if !ok {
+		skipf(t, "Skipping test: file IDs not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	cache := compat.NewHashCache()
+
+	want, err := compat.HashFile(name, compat.HashSHA256, compat.WithHashCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cache.Len(); got != 1 {
+		t.Fatalf("Len(): got %v, want 1", got)
+	}
+
+	key := compat.HashKey{
+		File:      fileKey,
+		Algorithm: compat.HashSHA256,
+		Size:      fi.Size(),
+		MTime:     fi.ModTime().UnixNano(),
+	}
+	if ctime := fi.CTime(); !ctime.IsZero() {
+		key.CTime = ctime.UnixNano()
+	}
+
+	sum, ok := cache.Get(key)
+	if !ok || !bytes.Equal(sum, want) {
+		t.Fatalf("Get(): got %x, %v, want %x, true", sum, ok, want)
+	}
+
+	// Prove the cached hash is returned, without reading the file.
+	fake := []byte("cached")
+	cache.Put(key, fake)
+
+	got, err := compat.HashFile(name, compat.HashSHA256, compat.WithHashCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, fake) {
+		t.Fatalf("HashFile(): got %x, want %x", got, fake)
+	}
+
+	// A changed file is hashed again.
+	err = os.WriteFile(name, []byte("hello, world"), perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = compat.HashFile(name, compat.HashSHA256, compat.WithHashCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(got, fake) || bytes.Equal(got, want) {
+		t.Fatalf("HashFile(): got %x, want a new hash", got)
+	}
+}
+
+func TestHashFileCacheRecent(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := compat.NewHashCache()
+
+	_, err = compat.HashFile(name, compat.HashXXH64, compat.WithHashCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cache.Len(); got != 0 {
+		t.Fatalf("Len(): got %v, want 0", got)
+	}
+}
+
+func TestHashCacheSaveLoad(t *testing.T) {
+	cache := compat.NewHashCache()
+
+	keys := []compat.HashKey{
+		{File: compat.FileKey{Partition: 1, File: 2}, Algorithm: compat.HashXXH64, Size: 5, MTime: 10, CTime: 11},
+		{File: compat.FileKey{Partition: 1, File: 2}, Algorithm: compat.HashSHA256, Size: 5, MTime: 10, CTime: 11},
+		{File: compat.FileKey{Partition: 3, File: 4}, Algorithm: compat.HashXXH64, Size: 6, MTime: 12},
+	}
+
+	for i, key := range keys {
+		cache.Put(key, []byte{byte(i)})
+	}
+
+	var buf1 bytes.Buffer
+
+	err := cache.Save(&buf1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded compat.MemHashCache
+
+	err = loaded.Load(bytes.NewReader(buf1.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := loaded.Len(), len(keys); got != want {
+		t.Fatalf("Len(): got %v, want %v", got, want)
+	}
+
+	for i, key := range keys {
+		sum, ok := loaded.Get(key)
+		if !ok || !bytes.Equal(sum, []byte{byte(i)}) {
+			t.Fatalf("Get(%+v): got %x, %v, want %x, true", key, sum, ok, []byte{byte(i)})
+		}
+	}
+
+	var buf2 bytes.Buffer
+
+	err = loaded.Save(&buf2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf1.String() != buf2.String() {
+		t.Fatalf("Save(): got %v, want %v", buf2.String(), buf1.String())
+	}
+
+	err = loaded.Load(bytes.NewReader([]byte("{")))
+	if err == nil {
+		t.Fatal("Load(): got nil, want an error")
+	}
+}
+
+func TestHashCacheCopies(t *testing.T) {
+	cache := compat.NewHashCache()
+	key := compat.HashKey{File: compat.FileKey{Partition: 1, File: 2}, Algorithm: compat.HashXXH64}
+
+	sum := []byte{1, 2, 3}
+	cache.Put(key, sum)
+	sum[0] = 9
+
+	got, _ := cache.Get(key)
+	if !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Fatalf("Get(): got %x, want %x (Put didn't copy the sum)", got, []byte{1, 2, 3})
+	}
+
+	got[0] = 9
+
+	got, _ = cache.Get(key)
+	if !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Fatalf("Get(): got %x, want %x (Get didn't copy the sum)", got, []byte{1, 2, 3})
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsHidden returns true if fi describes a hidden file. A file is hidden if:
+//
+//	Unix:    its name starts with a dot (.)
+//	macOS:   its name starts with a dot, or its UF_HIDDEN flag is set
+//	FreeBSD: its name starts with a dot, or its UF_HIDDEN flag is set
+//	Windows: its FILE_ATTRIBUTE_HIDDEN attribute is set
+//	Plan 9:  never, as Plan 9 has no hidden files
+//
+// The flags and attributes are read from fi.Sys(), so fi can be returned by
+// compat, or the os package.
+func IsHidden(fi os.FileInfo) bool {
+	if fi == nil {
+		return false
+	}
+
+	if dotHidden && isDotName(fi.Name()) {
+		return true
+	}
+
+	return hiddenSys(fi.Sys())
+}
+
+// IsHiddenPath returns true if the named file is hidden. If the file is a
+// symbolic link, IsHiddenPath reports on the link itself. See [IsHidden].
+// If there is an error, it will be of type [*PathError].
+func IsHiddenPath(name string) (bool, error) {
+	fi, err := os.Lstat(name)
+	if err != nil {
+		return false, err
+	}
+
+	return IsHidden(fi), nil
+}
+
+// SetHidden hides, or unhides, the named file, and returns the file's name,
+// which changes if the file is renamed. On macOS, FreeBSD and Windows, it sets,
+// or clears, the file's hidden flag. On other Unix systems, the only
+// mechanism is a dot (.) prefixed name, so, as a rename is not a pure
+// attribute change, SetHidden returns an *UnsupportedError, unless the
+// WithDotRename(true) option is passed.
+//
+// With WithDotRename(true), SetHidden also adds, or removes, the dot prefix
+// on macOS and FreeBSD, so a dot named file can be unhidden. Without it,
+// unhiding a dot named file returns an *UnsupportedError, and leaves the file
+// unchanged, as the file would stay hidden. If the new name already exists,
+// the file isn't renamed, and the error wraps os.ErrExist.
+// The option is ignored on Windows and Plan 9.
+// If the file is a symbolic link, SetHidden hides, or unhides, the link
+// itself, as IsHiddenPath does.
+// If there is an error, it will be of type [*PathError], or [*LinkError].
+func SetHidden(name string, hidden bool, opts ...Option) (string, error) {
+	options := buildOptions(opts...)
+
+	useFlag := supportedFileFlags.Has(FlagHidden)
+	useDot := dotHidden && options.dotRename
+
+	if !useFlag && !useDot {
+		return name, setHiddenError(name, &UnsupportedError{Op: "sethidden"})
+	}
+
+	// Clearing the flag alone wouldn't unhide a dot named file.
+	if !hidden && dotHidden && !useDot && isDotName(filepath.Base(name)) {
+		return name, setHiddenError(name, &UnsupportedError{Op: "sethidden"})
+	}
+
+	// Rename first, so the flag is unchanged if the new name exists.
+	if useDot {
+		var err error
+
+		name, err = renameDot(name, hidden, opts...)
+		if err != nil {
+			return name, err
+		}
+	}
+
+	if useFlag {
+		err := setHiddenFlag(name, hidden)
+		if err != nil {
+			return name, err
+		}
+	}
+
+	return name, nil
+}
+
+// setHiddenFlag sets, or clears, the hidden flag on name itself, and not on
+// the target of a symbolic link, so it agrees with IsHiddenPath.
+func setHiddenFlag(name string, hidden bool) error {
+	fi, err := os.Lstat(name)
+	if err != nil {
+		return err
+	}
+
+	if hiddenSys(fi.Sys()) == hidden {
+		return nil
+	}
+
+	return setHiddenSys(name, fi.Sys(), hidden)
+}
+
+// renameDot renames name to, or from, a dot prefixed name, without replacing
+// an existing file.
+func renameDot(name string, hidden bool, opts ...Option) (string, error) {
+	dir, base := filepath.Split(filepath.Clean(name))
+	if isDotName(base) == hidden {
+		return name, nil
+	}
+
+	newBase := "." + base
+	if !hidden {
+		newBase = strings.TrimPrefix(base, ".")
+	}
+
+	newName := dir + newBase
+
+	err := renameNoReplace(name, newName)
+	if err != nil {
+		return name, err
+	}
+
+	return newName, syncParents(buildOptions(opts...).durable, newName)
+}
+
+// isDotName returns true if name starts with a dot, and isn't "." or "..".
+func isDotName(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.TrimLeft(name, ".") != ""
+}
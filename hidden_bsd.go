@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin || freebsd
+
+// The darwin build flag includes ios
+
+package compat
+
+import (
+	"syscall"
+)
+
+const dotHidden = true
+
+func hiddenSys(sys any) bool {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok || st == nil {
+		return false
+	}
+
+	return uint32(st.Flags)&ufHidden != 0 //nolint:unconvert,nolintlint // Flags is an int32 on some systems
+}
+
+// setHiddenSys sets, or clears, UF_HIDDEN without following a symbolic link.
+func setHiddenSys(name string, sys any, hidden bool) error {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok || st == nil {
+		return setFlagsError(name, &UnsupportedError{Op: "setflags"})
+	}
+
+	uf := uint32(st.Flags) &^ ufHidden //nolint:unconvert,nolintlint // Flags is an int32 on some systems
+	if hidden {
+		uf |= ufHidden
+	}
+
+	err := lchflags(name, uf)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(darwin || freebsd || plan9 || windows)
+
+package compat
+
+const dotHidden = true
+
+func hiddenSys(_ any) bool {
+	return false
+}
+
+func setHiddenSys(name string, _ any, _ bool) error {
+	return setFlagsError(name, &UnsupportedError{Op: "setflags"})
+}
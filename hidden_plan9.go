@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build plan9
+
+package compat
+
+// Plan 9 has no hidden files, as ls(1) lists dot prefixed names.
+const dotHidden = false
+
+func hiddenSys(_ any) bool {
+	return false
+}
+
+func setHiddenSys(name string, _ any, _ bool) error {
+	return setFlagsError(name, &UnsupportedError{Op: "setflags"})
+}
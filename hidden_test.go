@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+// dotHidden is true if the OS hides dot (.) prefixed names.
+var dotHidden = runtime.GOOS != "windows" && runtime.GOOS != "plan9"
+
+// flagHidden is true if the OS has a hidden flag.
+var flagHidden = compat.SupportedFileFlags().Has(compat.FlagHidden)
+
+func createNamedFile(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	name = filepath.Join(dir, name)
+
+	err := os.WriteFile(name, helloBytes, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return name
+}
+
+func TestIsHiddenNil(t *testing.T) {
+	if compat.IsHidden(nil) {
+		t.Fatal("IsHidden(nil): got true, want false")
+	}
+}
+
+func TestIsHiddenDotName(t *testing.T) {
+	name := createNamedFile(t, tempDir(t), ".hidden")
+
+	got, err := compat.IsHiddenPath(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != dotHidden {
+		t.Fatalf("IsHiddenPath(%q): got %v, want %v", name, got, dotHidden)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := compat.IsHidden(fi); got != dotHidden {
+		t.Fatalf("IsHidden(%q): got %v, want %v", name, got, dotHidden)
+	}
+}
+
+func TestIsHiddenDotDirs(t *testing.T) {
+	for _, name := range []string{".", ".."} {
+		got, err := compat.IsHiddenPath(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got {
+			t.Fatalf("IsHiddenPath(%q): got true, want false", name)
+		}
+	}
+}
+
+func TestIsHiddenNotExist(t *testing.T) {
+	_, err := compat.IsHiddenPath(tempName(t))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("IsHiddenPath(): got %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestSetHidden(t *testing.T) {
+	name := createNamedFile(t, tempDir(t), "visible")
+
+	got, err := compat.SetHidden(name, true, compat.WithDotRename(false))
+	if !flagHidden {
+		if !compat.IsUnsupportedError(err) {
+			t.Fatalf("SetHidden(): got %v, want an unsupported error", err)
+		}
+
+		return
+	}
+
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: hidden flag not supported on this filesystem: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != name {
+		t.Fatalf("SetHidden(): got %q, want %q", got, name)
+	}
+
+	assertHidden(t, name, true)
+
+	_, err = compat.SetHidden(name, false, compat.WithDotRename(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertHidden(t, name, false)
+}
+
+func TestSetHiddenDotRename(t *testing.T) {
+	name := createNamedFile(t, tempDir(t), "visible")
+
+	hiddenName, err := compat.SetHidden(name, true, compat.WithDotRename(true))
+	if !dotHidden && !flagHidden {
+		if !compat.IsUnsupportedError(err) {
+			t.Fatalf("SetHidden(): got %v, want an unsupported error", err)
+		}
+
+		return
+	}
+
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: hidden flag not supported on this filesystem: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := name
+	if dotHidden {
+		want = filepath.Join(filepath.Dir(name), ".visible")
+	}
+
+	if hiddenName != want {
+		t.Fatalf("SetHidden(): got %q, want %q", hiddenName, want)
+	}
+
+	assertHidden(t, hiddenName, true)
+
+	visibleName, err := compat.SetHidden(hiddenName, false, compat.WithDotRename(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if visibleName != name {
+		t.Fatalf("SetHidden(): got %q, want %q", visibleName, name)
+	}
+
+	assertHidden(t, visibleName, false)
+}
+
+func TestSetHiddenDotRenameExists(t *testing.T) {
+	if !dotHidden {
+		skipf(t, "Skipping test: dot prefixed names are not hidden on %v", runtime.GOOS)
+
+		return
+	}
+
+	dir := tempDir(t)
+	name := createNamedFile(t, dir, "visible")
+	_ = createNamedFile(t, dir, ".visible")
+
+	got, err := compat.SetHidden(name, true, compat.WithDotRename(true))
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: hidden flag not supported on this filesystem: %v", err)
+
+		return
+	}
+
+	if !errors.Is(err, os.ErrExist) {
+		t.Fatalf("SetHidden(): got %v, want %v", err, os.ErrExist)
+	}
+
+	if got != name {
+		t.Fatalf("SetHidden(): got %q, want %q", got, name)
+	}
+
+	_, err = os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetHiddenDotRenameUnhideExists(t *testing.T) {
+	if !dotHidden {
+		skipf(t, "Skipping test: dot prefixed names are not hidden on %v", runtime.GOOS)
+
+		return
+	}
+
+	dir := tempDir(t)
+	name := createNamedFile(t, dir, ".visible")
+	_ = createNamedFile(t, dir, "visible")
+
+	got, err := compat.SetHidden(name, false, compat.WithDotRename(true))
+	if !errors.Is(err, os.ErrExist) {
+		t.Fatalf("SetHidden(): got %v, want %v", err, os.ErrExist)
+	}
+
+	if got != name {
+		t.Fatalf("SetHidden(): got %q, want %q", got, name)
+	}
+
+	assertHidden(t, name, true)
+}
+
+func TestSetHiddenDotNameNoRename(t *testing.T) {
+	name := createNamedFile(t, tempDir(t), ".hidden")
+
+	got, err := compat.SetHidden(name, false, compat.WithDotRename(false))
+	if got != name {
+		t.Fatalf("SetHidden(): got %q, want %q", got, name)
+	}
+
+	if !dotHidden {
+		if err != nil && !compat.IsUnsupportedError(err) {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("SetHidden(): got %v, want an unsupported error", err)
+	}
+
+	assertHidden(t, name, true)
+}
+
+func TestSetHiddenSymlink(t *testing.T) {
+	if !supportsSymlinks(t) {
+		return
+	}
+
+	dir := tempDir(t)
+	target := createNamedFile(t, dir, "target")
+	link := filepath.Join(dir, "link")
+
+	err := os.Symlink(target, link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.SetHidden(link, true, compat.WithDotRename(false))
+	if !flagHidden {
+		if !compat.IsUnsupportedError(err) {
+			t.Fatalf("SetHidden(): got %v, want an unsupported error", err)
+		}
+
+		return
+	}
+
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: hidden flag not supported on this filesystem: %v", err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The flag is set on the link, not its target, as IsHiddenPath uses Lstat.
+	assertHidden(t, link, true)
+	assertHidden(t, target, false)
+
+	_, err = compat.SetHidden(link, false, compat.WithDotRename(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertHidden(t, link, false)
+}
+
+func assertHidden(t *testing.T, name string, want bool) {
+	t.Helper()
+
+	got, err := compat.IsHiddenPath(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Fatalf("IsHiddenPath(%q): got %v, want %v", name, got, want)
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package compat
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/rasa/compat/golang"
+)
+
+// Windows doesn't hide dot prefixed names.
+const dotHidden = false
+
+func hiddenSys(sys any) bool {
+	var attrs uint32
+
+	switch i := sys.(type) {
+	case *syscall.Win32FileAttributeData:
+		if i == nil {
+			return false
+		}
+
+		attrs = i.FileAttributes
+	case *syscall.ByHandleFileInformation:
+		if i == nil {
+			return false
+		}
+
+		attrs = i.FileAttributes
+	case *windows.ByHandleFileInformation:
+		if i == nil {
+			return false
+		}
+
+		attrs = i.FileAttributes
+	default:
+		return false
+	}
+
+	return attrs&windows.FILE_ATTRIBUTE_HIDDEN != 0
+}
+
+// fileBasicInfo is FILE_BASIC_INFO, padded to 8 bytes, as windows/386
+// rejects the unpadded struct. Zero times are left unchanged.
+type fileBasicInfo struct {
+	CreationTime   int64
+	LastAccessTime int64
+	LastWriteTime  int64
+	ChangedTime    int64
+	FileAttributes uint32
+	_              uint32
+}
+
+// setHiddenSys sets, or clears, FILE_ATTRIBUTE_HIDDEN without following a
+// symbolic link, as the link is opened with FILE_FLAG_OPEN_REPARSE_POINT.
+func setHiddenSys(name string, _ any, hidden bool) error {
+	path16, err := windows.UTF16PtrFromString(golang.FixLongPath(name))
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	h, err := windows.CreateFile(path16, windows.FILE_READ_ATTRIBUTES|windows.FILE_WRITE_ATTRIBUTES,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS|windows.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	var d windows.ByHandleFileInformation
+
+	err = windows.GetFileInformationByHandle(h, &d)
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	info := fileBasicInfo{FileAttributes: d.FileAttributes &^ windows.FILE_ATTRIBUTE_HIDDEN}
+	if hidden {
+		info.FileAttributes |= windows.FILE_ATTRIBUTE_HIDDEN
+	}
+
+	if info.FileAttributes == 0 {
+		info.FileAttributes = windows.FILE_ATTRIBUTE_NORMAL
+	}
+
+	err = windows.SetFileInformationByHandle(h, windows.FileBasicInfo,
+		(*byte)(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	if err != nil {
+		return setFlagsError(name, err)
+	}
+
+	return nil
+}
@@ -9,6 +9,11 @@ import "os"
 
 // Link creates newname as a hard link to the oldname file.
 // If there is an error, it will be of type *LinkError.
-func Link(oldname, newname string) error {
-	return os.Link(oldname, newname)
+func Link(oldname, newname string, opts ...Option) error {
+	err := os.Link(oldname, newname)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(buildOptions(opts...).durable, newname)
 }
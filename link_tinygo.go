@@ -7,7 +7,7 @@ package compat
 
 // Link creates newname as a hard link to the oldname file.
 // If there is an error, it will be of type *LinkError.
-func Link(_, _ string) error {
+func Link(_, _ string, _ ...Option) error {
 	// See https://github.com/tinygo-org/tinygo/blob/3869f768/src/os/errors.go#L29
 	return &UnimplementedError{"link"}
 }
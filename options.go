@@ -7,7 +7,9 @@
 package compat
 
 import (
+	"context"
 	"os"
+	"time"
 )
 
 // Options define the behavior of `WriteFile()`, etc.
@@ -21,6 +23,19 @@ type Options struct {
 	readOnlyMode     ReadOnlyMode // default 0
 	retrySeconds     float64      // default 0.0
 	setSymlinkOwner  bool         // default false
+
+	timeGranularity time.Duration // default 0
+	hashCache       HashCache     // default nil
+	hashCacheSet    bool          // default false
+	dotRename       bool          // default false
+	resolver        Resolver      // default nil
+	resolverSet     bool          // default false
+	durable         bool          // default false
+
+	ctx              context.Context // default nil
+	progress         func(int64)     // default nil
+	progressInterval int64           // default 0
+	tempPattern      string          // default ""
 }
 
 // Option functions modify Options.
@@ -116,3 +131,134 @@ func WithSetSymlinkOwner(setSymlinkOwner bool) Option {
 		opts.setSymlinkOwner = setSymlinkOwner
 	}
 }
+
+// WithTimeGranularity sets the tolerance used when comparing times. Times
+// that differ by less than granularity are considered equal. To compare
+// times on a filesystem with a coarse resolution, such as FAT's 2 seconds,
+// use the volume.Filesystem's MTimeGranularity or CTimeGranularity value.
+// The default is 0, which means times must be equal.
+// Used by the Diff function.
+func WithTimeGranularity(granularity time.Duration) Option {
+	return func(opts *Options) {
+		opts.timeGranularity = granularity
+	}
+}
+
+// WithHashCache sets the cache used to look up, and save, file hashes.
+// The default is nil, which means hashes are not cached.
+// Used by the HashFile function.
+func WithHashCache(cache HashCache) Option {
+	return func(opts *Options) {
+		opts.hashCache = cache
+		opts.hashCacheSet = true
+	}
+}
+
+// WithDotRename permits SetHidden to rename a file to, or from, a dot (.)
+// prefixed name, on systems that hide dot prefixed names.
+// The default is false, as a rename is not a pure attribute change.
+// Used by the SetHidden function.
+func WithDotRename(dotRename bool) Option {
+	return func(opts *Options) {
+		opts.dotRename = dotRename
+	}
+}
+
+// WithResolver sets the resolver used to map user and group IDs to names,
+// and back. To set the resolver used by FileInfo's User() and Group()
+// functions, pass the option to SetOptions.
+// The default is nil, which means a CachedResolver, caching the os/user
+// package's results, is used.
+// The option is ignored by FileInfo on Windows and Plan 9, as their names
+// are read from the file.
+// Used by the ChownNames function, and FileInfo's User() and Group()
+// functions.
+func WithResolver(r Resolver) Option {
+	return func(opts *Options) {
+		opts.resolver = r
+		opts.resolverSet = true
+	}
+}
+
+// WithDurability fsyncs the directories containing the files that are
+// created, renamed, linked, or removed, so the change to the directory
+// survives a crash, or power loss, and not just the file's contents.
+// The default is false, as an atomic rename is not a durable one.
+//
+// The sequence is:
+//
+//   - On Unix, the file is fsynced (WriteReader only), the operation is
+//     performed, and then each containing directory is opened, and fsynced.
+//     On macOS and iOS, fcntl(F_FULLFSYNC) is used instead of fsync.
+//     A rename across directories syncs the destination's directory first,
+//     then the source's.
+//   - On Windows, renames already use MoveFileEx's MOVEFILE_WRITE_THROUGH
+//     flag. The directory is then opened for writing, and flushed with
+//     FlushFileBuffers. As NTFS journals directory changes, the flush is
+//     skipped if access is denied, or the filesystem doesn't support it.
+//   - On Plan 9, the directory is synced with a null wstat(5).
+//   - On systems that can't sync a directory (such as js and wasip1),
+//     the errors are ignored.
+//
+// Used by the AtomicWriter type, and the CleanupStaleTemps, Create,
+// CreateTemp, Link, Mkdir, MkdirAll, MkdirTemp, OpenFile, Remove, RemoveAll,
+// Rename, SetHidden, Symlink, and WriteReader functions. Create and OpenFile only sync
+// the directory if the file didn't exist.
+func WithDurability(durable bool) Option {
+	return func(opts *Options) {
+		opts.durable = durable
+	}
+}
+
+// WithContext sets the context that cancels a write. The context is checked
+// before each read from the reader, and before the file is renamed into
+// place, so a read that blocks isn't interrupted.
+// When cancelled in atomic mode, the temporary file is removed, and the
+// destination is left unchanged. Otherwise, the destination may be left
+// partially written.
+// If there is an error, it will wrap the context's error.
+// The default is nil, which means the write can't be cancelled.
+// The option is per-call only, so SetOptions ignores it.
+// Used by the WriteFile and WriteReader functions.
+func WithContext(ctx context.Context) Option {
+	return func(opts *Options) {
+		opts.ctx = ctx
+	}
+}
+
+// WithProgress sets a function that is called with the total number of bytes
+// written so far, every time at least the WithProgressInterval number of
+// bytes have been written, and once more when the write completes.
+// The function is called on the writing goroutine.
+// The default is nil, which means progress isn't reported.
+// The option is per-call only, so SetOptions ignores it.
+// Used by the WriteFile and WriteReader functions.
+func WithProgress(progress func(written int64)) Option {
+	return func(opts *Options) {
+		opts.progress = progress
+	}
+}
+
+// WithProgressInterval sets the minimum number of bytes written between
+// calls to the WithProgress function.
+// The default is 0, which means the function is called after every write.
+// Used by the WriteFile and WriteReader functions.
+func WithProgressInterval(bytes int64) Option {
+	return func(opts *Options) {
+		opts.progressInterval = bytes
+	}
+}
+
+// WithTempPattern sets the pattern of the temporary file names used by atomic
+// writes, as CreateTemp's pattern: the random part replaces the last "*",
+// or is appended, if there's no "*". The pattern must have a prefix, or a
+// suffix, so CleanupStaleTemps can find the files, and can't contain a path
+// separator.
+// The default is "", which means "~*.tmp" is used.
+// Used by the CleanupStaleTemps, NewAtomicWriter, WriteFile, and WriteReader
+// functions.
+func WithTempPattern(pattern string) Option {
+	return func(opts *Options) {
+		opts.tempPattern = pattern
+	}
+}
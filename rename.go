@@ -3,6 +3,11 @@
 
 package compat
 
+import (
+	"errors"
+	"os"
+)
+
 // Rename atomically replaces the destination file or directory with the
 // source. It is guaranteed to either replace the target file entirely, or not
 // change either file.
@@ -12,5 +17,43 @@ package compat
 // errors.ErrUnsupported and leaves the destination unchanged.
 // To work around this issue, use the WithNonAtomicReplace option.
 func Rename(source, destination string, opts ...Option) error {
-	return rename(source, destination, opts...)
+	err := rename(source, destination, opts...)
+	if err != nil {
+		return err
+	}
+
+	return syncParents(buildOptions(opts...).durable, destination, source)
+}
+
+// renameNoReplaceLink renames source to destination by linking destination to
+// source, then removing source, so it fails if destination exists. If source
+// can't be linked, such as a directory, or on a filesystem without hard links,
+// it checks that destination doesn't exist, and then renames source.
+func renameNoReplaceLink(source, destination string) error {
+	err := os.Link(source, destination)
+	if err == nil {
+		err = os.Remove(source)
+		if err != nil {
+			_ = os.Remove(destination)
+
+			return renameError(source, destination, err)
+		}
+
+		return nil
+	}
+
+	if errors.Is(err, os.ErrExist) {
+		return renameError(source, destination, os.ErrExist)
+	}
+
+	_, err = os.Lstat(destination)
+	if err == nil {
+		return renameError(source, destination, os.ErrExist)
+	}
+
+	if !os.IsNotExist(err) {
+		return renameError(source, destination, err)
+	}
+
+	return os.Rename(source, destination)
 }
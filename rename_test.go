@@ -4,6 +4,9 @@
 package compat_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -140,3 +143,86 @@ func TestRenameWithNonAtomicReplace(t *testing.T) {
 		t.Fatalf("renaming '%v' to '%v': %v", old, gnu, err)
 	}
 }
+
+var renameNoReplaceFuncs = map[string]func(source, destination string) error{
+	"renameNoReplace":     compat.RenameNoReplace,
+	"renameNoReplaceLink": compat.RenameNoReplaceLink,
+}
+
+func TestRenameNoReplace(t *testing.T) {
+	for name, fn := range renameNoReplaceFuncs {
+		t.Run(name, func(t *testing.T) {
+			dir := tempDir(t)
+			src := createNamedFile(t, dir, "src")
+			dst := filepath.Join(dir, "dst")
+
+			err := fn(src, dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = os.Lstat(src)
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+			}
+
+			assertOnlyFile(t, dir, "dst")
+		})
+	}
+}
+
+func TestRenameNoReplaceExists(t *testing.T) {
+	for name, fn := range renameNoReplaceFuncs {
+		t.Run(name, func(t *testing.T) {
+			dir := tempDir(t)
+			src := createNamedFile(t, dir, "src")
+			dst := filepath.Join(dir, "dst")
+
+			err := os.WriteFile(dst, []byte("dst"), perm600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = fn(src, dst)
+			if !errors.Is(err, os.ErrExist) {
+				t.Fatalf("got %v, want %v", err, os.ErrExist)
+			}
+
+			got, err := os.ReadFile(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != "dst" {
+				t.Fatalf("got %q, want %q", got, "dst")
+			}
+
+			_, err = os.Lstat(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRenameNoReplaceDir(t *testing.T) {
+	for name, fn := range renameNoReplaceFuncs {
+		t.Run(name, func(t *testing.T) {
+			dir := tempDir(t)
+			src := filepath.Join(dir, "src")
+			dst := filepath.Join(dir, "dst")
+
+			err := os.Mkdir(src, perm700)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = fn(src, dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertOnlyFile(t, dir, "dst")
+		})
+	}
+}
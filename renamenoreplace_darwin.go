@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin
+
+package compat
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// renameNoReplace renames source to destination, failing with EEXIST if
+// destination exists.
+func renameNoReplace(source, destination string) error {
+	err := unix.RenamexNp(source, destination, unix.RENAME_EXCL)
+	if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOTSUP) {
+		// The filesystem doesn't support RENAME_EXCL.
+		return renameNoReplaceLink(source, destination)
+	}
+
+	if err != nil {
+		return renameError(source, destination, err)
+	}
+
+	return nil
+}
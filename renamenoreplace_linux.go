@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package compat
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// renameNoReplace renames source to destination, failing with EEXIST if
+// destination exists.
+func renameNoReplace(source, destination string) error {
+	err := unix.Renameat2(unix.AT_FDCWD, source, unix.AT_FDCWD, destination, unix.RENAME_NOREPLACE)
+	if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOSYS) || errors.Is(err, unix.EOPNOTSUPP) {
+		// The kernel, or the filesystem, doesn't support RENAME_NOREPLACE.
+		return renameNoReplaceLink(source, destination)
+	}
+
+	if err != nil {
+		return renameError(source, destination, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(darwin || linux)
+
+package compat
+
+// renameNoReplace renames source to destination, failing with EEXIST if
+// destination exists.
+func renameNoReplace(source, destination string) error {
+	return renameNoReplaceLink(source, destination)
+}
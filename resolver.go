@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"os/user"
+	"strconv"
+	"time"
+)
+
+// A Resolver maps user and group IDs to names, and back.
+// A Resolver must be safe for concurrent use by multiple goroutines.
+//
+// A Resolver should return a [user.UnknownUserIdError],
+// [user.UnknownGroupIdError], [user.UnknownUserError], or
+// [user.UnknownGroupError] if an ID, or name, does not exist, so a
+// [CachedResolver] can cache the result.
+type Resolver interface {
+	UserName(uid int) (string, error)
+	GroupName(gid int) (string, error)
+	UserID(name string) (int, error)
+	GroupID(name string) (int, error)
+}
+
+// The default resolver's size and TTL.
+const (
+	defaultResolverSize = 1024
+	defaultResolverTTL  = 5 * time.Minute
+)
+
+// defaultResolver is used when the WithResolver option isn't set.
+var defaultResolver Resolver = NewCachedResolver(OSResolver{}, defaultResolverSize, defaultResolverTTL)
+
+// resolver returns the resolver set by the WithResolver option, or the
+// default resolver, which caches the results of the os/user package.
+func resolver(options Options) Resolver {
+	if options.resolver != nil {
+		return options.resolver
+	}
+
+	return defaultResolver
+}
+
+// OSResolver is a [Resolver] that uses the os/user package, without caching.
+type OSResolver struct{}
+
+// UserName implements [Resolver].
+func (OSResolver) UserName(uid int) (string, error) {
+	u, err := user.LookupId(strconv.Itoa(uid))
+	if err != nil {
+		return "", err
+	}
+
+	return u.Username, nil
+}
+
+// GroupName implements [Resolver].
+func (OSResolver) GroupName(gid int) (string, error) {
+	g, err := user.LookupGroupId(strconv.Itoa(gid))
+	if err != nil {
+		return "", err
+	}
+
+	return g.Name, nil
+}
+
+// UserID implements [Resolver].
+func (OSResolver) UserID(name string) (int, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return UnknownID, err
+	}
+
+	return strconv.Atoi(u.Uid)
+}
+
+// GroupID implements [Resolver].
+func (OSResolver) GroupID(name string) (int, error) {
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return UnknownID, err
+	}
+
+	return strconv.Atoi(g.Gid)
+}
+
+// StaticResolver is a [Resolver] that uses fixed maps, such as the names
+// read from a tar archive, or an offline passwd file.
+// A StaticResolver is safe for concurrent use, if it isn't modified.
+type StaticResolver struct {
+	users    map[int]string
+	groups   map[int]string
+	userIDs  map[string]int
+	groupIDs map[string]int
+}
+
+// NewStaticResolver returns a StaticResolver that maps the IDs in users,
+// and groups, to names. The maps are copied.
+func NewStaticResolver(users, groups map[int]string) *StaticResolver {
+	r := &StaticResolver{
+		users:    make(map[int]string, len(users)),
+		groups:   make(map[int]string, len(groups)),
+		userIDs:  make(map[string]int, len(users)),
+		groupIDs: make(map[string]int, len(groups)),
+	}
+
+	for id, name := range users {
+		r.users[id] = name
+		r.userIDs[name] = id
+	}
+
+	for id, name := range groups {
+		r.groups[id] = name
+		r.groupIDs[name] = id
+	}
+
+	return r
+}
+
+// UserName implements [Resolver].
+func (r *StaticResolver) UserName(uid int) (string, error) {
+	name, ok := r.users[uid]
+	if !ok {
+		return "", user.UnknownUserIdError(uid)
+	}
+
+	return name, nil
+}
+
+// GroupName implements [Resolver].
+func (r *StaticResolver) GroupName(gid int) (string, error) {
+	name, ok := r.groups[gid]
+	if !ok {
+		return "", user.UnknownGroupIdError(strconv.Itoa(gid))
+	}
+
+	return name, nil
+}
+
+// UserID implements [Resolver].
+func (r *StaticResolver) UserID(name string) (int, error) {
+	id, ok := r.userIDs[name]
+	if !ok {
+		return UnknownID, user.UnknownUserError(name)
+	}
+
+	return id, nil
+}
+
+// GroupID implements [Resolver].
+func (r *StaticResolver) GroupID(name string) (int, error) {
+	id, ok := r.groupIDs[name]
+	if !ok {
+		return UnknownID, user.UnknownGroupError(name)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"container/list"
+	"errors"
+	"os/user"
+	"sync"
+	"time"
+)
+
+// A CachedResolver is a [Resolver] that caches the results of another
+// Resolver, in a bounded, least recently used (LRU) cache, whose entries
+// expire after a time to live (TTL). IDs, and names, that don't exist are
+// cached too (negative caching). Other errors are not cached.
+// A CachedResolver is safe for concurrent use by multiple goroutines.
+type CachedResolver struct {
+	resolver Resolver
+	size     int
+	ttl      time.Duration
+
+	mux     sync.Mutex
+	entries map[resolverKey]*list.Element
+	lru     list.List
+}
+
+type resolverKind uint8
+
+const (
+	resolveUserName resolverKind = iota
+	resolveGroupName
+	resolveUserID
+	resolveGroupID
+)
+
+type resolverKey struct {
+	kind resolverKind
+	id   int
+	name string
+}
+
+type resolverEntry struct {
+	key     resolverKey
+	name    string
+	id      int
+	err     error
+	expires time.Time
+}
+
+// NewCachedResolver returns a CachedResolver that caches up to size results
+// of r, for ttl. If size is less than 1, the cache is unbounded. If ttl is
+// 0, results don't expire.
+func NewCachedResolver(r Resolver, size int, ttl time.Duration) *CachedResolver {
+	return &CachedResolver{
+		resolver: r,
+		size:     size,
+		ttl:      ttl,
+		entries:  make(map[resolverKey]*list.Element),
+	}
+}
+
+// UserName implements [Resolver].
+func (c *CachedResolver) UserName(uid int) (string, error) {
+	e := c.lookup(resolverKey{kind: resolveUserName, id: uid}, func(e *resolverEntry) {
+		e.name, e.err = c.resolver.UserName(uid)
+	})
+
+	return e.name, e.err
+}
+
+// GroupName implements [Resolver].
+func (c *CachedResolver) GroupName(gid int) (string, error) {
+	e := c.lookup(resolverKey{kind: resolveGroupName, id: gid}, func(e *resolverEntry) {
+		e.name, e.err = c.resolver.GroupName(gid)
+	})
+
+	return e.name, e.err
+}
+
+// UserID implements [Resolver].
+func (c *CachedResolver) UserID(name string) (int, error) {
+	e := c.lookup(resolverKey{kind: resolveUserID, name: name}, func(e *resolverEntry) {
+		e.id, e.err = c.resolver.UserID(name)
+	})
+
+	return e.id, e.err
+}
+
+// GroupID implements [Resolver].
+func (c *CachedResolver) GroupID(name string) (int, error) {
+	e := c.lookup(resolverKey{kind: resolveGroupID, name: name}, func(e *resolverEntry) {
+		e.id, e.err = c.resolver.GroupID(name)
+	})
+
+	return e.id, e.err
+}
+
+// Len returns the number of results in the cache, including expired ones.
+func (c *CachedResolver) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.lru.Len()
+}
+
+// Purge removes every result from the cache.
+func (c *CachedResolver) Purge() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	clear(c.entries)
+	c.lru.Init()
+}
+
+// lookup returns the cached entry for key, or calls resolve to populate a new
+// entry, and caches it. The lock isn't held while resolve runs, as lookups
+// can be slow, so concurrent misses may resolve the same key more than once.
+func (c *CachedResolver) lookup(key resolverKey, resolve func(e *resolverEntry)) resolverEntry {
+	now := time.Now()
+
+	c.mux.Lock()
+
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*resolverEntry) //nolint:forcetypeassert
+		if c.ttl == 0 || now.Before(e.expires) {
+			c.lru.MoveToFront(elem)
+			c.mux.Unlock()
+
+			return *e
+		}
+
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+
+	c.mux.Unlock()
+
+	e := resolverEntry{key: key}
+	resolve(&e)
+
+	if e.err != nil && !isUnknownIDError(e.err) {
+		return e
+	}
+
+	e.expires = now.Add(c.ttl)
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+	}
+
+	c.entries[key] = c.lru.PushFront(&e)
+
+	for c.size > 0 && c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*resolverEntry).key) //nolint:forcetypeassert
+	}
+
+	return e
+}
+
+// isUnknownIDError returns true if err reports that a user, or group, ID, or
+// name, doesn't exist.
+func isUnknownIDError(err error) bool {
+	var (
+		uidErr   user.UnknownUserIdError
+		gidErr   user.UnknownGroupIdError
+		userErr  user.UnknownUserError
+		groupErr user.UnknownGroupError
+	)
+
+	return errors.As(err, &uidErr) || errors.As(err, &gidErr) ||
+		errors.As(err, &userErr) || errors.As(err, &groupErr)
+}
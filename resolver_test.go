@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"errors"
+	"os/user"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rasa/compat"
+)
+
+var errResolver = errors.New("resolver unavailable")
+
+// countingResolver counts the lookups passed to a StaticResolver.
+type countingResolver struct {
+	*compat.StaticResolver
+
+	calls atomic.Int32
+	err   error
+}
+
+func newCountingResolver() *countingResolver {
+	return &countingResolver{
+		StaticResolver: compat.NewStaticResolver(
+			map[int]string{1: "alice", 2: "bob", 3: "carol"},
+			map[int]string{10: "staff"},
+		),
+	}
+}
+
+func (r *countingResolver) UserName(uid int) (string, error) {
+	r.calls.Add(1)
+
+	if r.err != nil {
+		return "", r.err
+	}
+
+	return r.StaticResolver.UserName(uid)
+}
+
+func TestStaticResolver(t *testing.T) {
+	r := compat.NewStaticResolver(map[int]string{1: "alice"}, map[int]string{10: "staff"})
+
+	name, err := r.UserName(1)
+	if err != nil || name != "alice" {
+		t.Fatalf("UserName(1): got %q, %v, want %q", name, err, "alice")
+	}
+
+	id, err := r.UserID("alice")
+	if err != nil || id != 1 {
+		t.Fatalf("UserID(alice): got %d, %v, want 1", id, err)
+	}
+
+	name, err = r.GroupName(10)
+	if err != nil || name != "staff" {
+		t.Fatalf("GroupName(10): got %q, %v, want %q", name, err, "staff")
+	}
+
+	id, err = r.GroupID("staff")
+	if err != nil || id != 10 {
+		t.Fatalf("GroupID(staff): got %d, %v, want 10", id, err)
+	}
+
+	var uidErr user.UnknownUserIdError
+	if _, err = r.UserName(2); !errors.As(err, &uidErr) {
+		t.Fatalf("UserName(2): got %v, want %T", err, uidErr)
+	}
+
+	var gidErr user.UnknownGroupIdError
+	if _, err = r.GroupName(2); !errors.As(err, &gidErr) {
+		t.Fatalf("GroupName(2): got %v, want %T", err, gidErr)
+	}
+
+	var userErr user.UnknownUserError
+	if _, err = r.UserID("bob"); !errors.As(err, &userErr) {
+		t.Fatalf("UserID(bob): got %v, want %T", err, userErr)
+	}
+
+	var groupErr user.UnknownGroupError
+	if _, err = r.GroupID("wheel"); !errors.As(err, &groupErr) {
+		t.Fatalf("GroupID(wheel): got %v, want %T", err, groupErr)
+	}
+}
+
+func TestCachedResolverHit(t *testing.T) {
+	r := newCountingResolver()
+	c := compat.NewCachedResolver(r, 10, time.Hour)
+
+	for range 3 {
+		name, err := c.UserName(1)
+		if err != nil || name != "alice" {
+			t.Fatalf("UserName(1): got %q, %v, want %q", name, err, "alice")
+		}
+	}
+
+	if got := r.calls.Load(); got != 1 {
+		t.Fatalf("lookups: got %d, want 1", got)
+	}
+}
+
+func TestCachedResolverNegative(t *testing.T) {
+	r := newCountingResolver()
+	c := compat.NewCachedResolver(r, 10, time.Hour)
+
+	var uidErr user.UnknownUserIdError
+
+	for range 3 {
+		_, err := c.UserName(99)
+		if !errors.As(err, &uidErr) {
+			t.Fatalf("UserName(99): got %v, want %T", err, uidErr)
+		}
+	}
+
+	if got := r.calls.Load(); got != 1 {
+		t.Fatalf("lookups: got %d, want 1", got)
+	}
+}
+
+func TestCachedResolverErrorNotCached(t *testing.T) {
+	r := newCountingResolver()
+	r.err = errResolver
+	c := compat.NewCachedResolver(r, 10, time.Hour)
+
+	for range 3 {
+		_, err := c.UserName(1)
+		if !errors.Is(err, errResolver) {
+			t.Fatalf("UserName(1): got %v, want %v", err, errResolver)
+		}
+	}
+
+	if got := r.calls.Load(); got != 3 {
+		t.Fatalf("lookups: got %d, want 3", got)
+	}
+
+	if got := c.Len(); got != 0 {
+		t.Fatalf("Len(): got %d, want 0", got)
+	}
+}
+
+func TestCachedResolverLRU(t *testing.T) {
+	r := newCountingResolver()
+	c := compat.NewCachedResolver(r, 2, 0)
+
+	for _, uid := range []int{1, 2, 1, 3} {
+		_, err := c.UserName(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len(): got %d, want 2", got)
+	}
+
+	// 2 was the least recently used, so it was evicted, and 1 was not.
+	r.calls.Store(0)
+
+	for _, uid := range []int{1, 3, 2} {
+		_, err := c.UserName(uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := r.calls.Load(); got != 1 {
+		t.Fatalf("lookups: got %d, want 1", got)
+	}
+}
+
+func TestCachedResolverTTL(t *testing.T) {
+	r := newCountingResolver()
+	c := compat.NewCachedResolver(r, 10, time.Millisecond)
+
+	_, err := c.UserName(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = c.UserName(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r.calls.Load(); got != 2 {
+		t.Fatalf("lookups: got %d, want 2", got)
+	}
+}
+
+func TestCachedResolverPurge(t *testing.T) {
+	r := newCountingResolver()
+	c := compat.NewCachedResolver(r, 10, 0)
+
+	_, _ = c.UserName(1)
+	_, _ = c.GroupName(10)
+	_, _ = c.UserID("alice")
+	_, _ = c.GroupID("staff")
+
+	if got := c.Len(); got != 4 {
+		t.Fatalf("Len(): got %d, want 4", got)
+	}
+
+	c.Purge()
+
+	if got := c.Len(); got != 0 {
+		t.Fatalf("Len(): got %d, want 0", got)
+	}
+}
+
+func TestCachedResolverConcurrent(t *testing.T) {
+	r := newCountingResolver()
+	c := compat.NewCachedResolver(r, 2, 0)
+
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 100 {
+				_, _ = c.UserName((i + j) % 4)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := c.Len(); got > 2 {
+		t.Fatalf("Len(): got %d, want <= 2", got)
+	}
+}
+
+func TestWithResolverFileInfo(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		skipf(t, "Skipping test: names are read from the file on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := compat.NewStaticResolver(map[int]string{fi.UID(): "alice"}, map[int]string{fi.GID(): "staff"})
+
+	compat.SetOptions(compat.WithResolver(r))
+	defer compat.SetOptions(compat.WithResolver(nil))
+
+	fi, err = compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.User() != "alice" || fi.Group() != "staff" {
+		t.Fatalf("User(), Group(): got %q, %q, want %q, %q", fi.User(), fi.Group(), "alice", "staff")
+	}
+}
+
+func TestWithResolverChownNames(t *testing.T) {
+	if runtime.GOOS == "plan9" {
+		skipf(t, "Skipping test: names are not mapped to IDs on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want.UID() == compat.UnknownID || want.GID() == compat.UnknownID {
+		skipf(t, "Skipping test: UID and GID not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	r := compat.NewStaticResolver(map[int]string{want.UID(): "alice"}, map[int]string{want.GID(): "staff"})
+
+	err = compat.ChownNames(name, "alice", "staff", compat.WithResolver(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.UID() != want.UID() || got.GID() != want.GID() {
+		t.Fatalf("ChownNames(): got %d:%d, want %d:%d", got.UID(), got.GID(), want.UID(), want.GID())
+	}
+
+	var userErr user.UnknownUserError
+
+	err = compat.ChownNames(name, "bob", "", compat.WithResolver(r))
+	if !errors.As(err, &userErr) {
+		t.Fatalf("ChownNames(bob): got %v, want %T", err, userErr)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package sid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The identifier authorities used by the mapping.
+// See https://learn.microsoft.com/en-us/windows/win32/secauthz/well-known-sids
+const (
+	WorldAuthority = 1
+	NTAuthority    = 5
+)
+
+// The NT authority subauthorities used by the mapping.
+const (
+	logonIDs      = 5
+	ntNonUnique   = 21
+	builtinDomain = 32
+)
+
+// The POSIX IDs, and ID ranges, used by the mapping.
+const (
+	// CurrentSessionID is the ID that logon SIDs (S-1-5-5-X-Y) are mapped to.
+	CurrentSessionID = 0xfff
+	// EveryoneID is the ID that Everyone (S-1-1-0) is mapped to.
+	EveryoneID = 0x30201
+
+	builtinOffset   = 0x20000
+	secondaryOffset = 0x30000
+	primaryOffset   = 0x40000
+	rangeSize       = 0x10000
+)
+
+// ErrUnmapped is returned when a SID, or a POSIX ID, has no mapping.
+var ErrUnmapped = errors.New("no POSIX mapping")
+
+// A Mapper maps SIDs to, and from, POSIX IDs, using the mapping that compat
+// uses for the UID() and GID() values on Windows:
+//
+//	SID                     Account                POSIX ID
+//	S-1-1-0                 Everyone               0x30201
+//	S-1-5-5-X-Y             logon session          0xfff
+//	S-1-5-32-RID            BUILTIN domain         0x20000 + RID  (Administrators, S-1-5-32-544: 0x20220)
+//	S-1-5-21-X-Y-Z-RID      Primary domain         0x40000 + RID
+//	S-1-5-21-X-Y-Z-RID      any other domain       0x30000 + RID
+//
+// Other SIDs, such as S-1-5-18 (SYSTEM), aren't mapped.
+//
+// The mapping is based on Cygwin's, but differs from the one that current
+// Cygwin releases use.
+// See https://cygwin.com/cygwin-ug-net/ntsec.html#ntsec-mapping
+type Mapper struct {
+	// Primary is the domain SID, S-1-5-21-X-Y-Z, whose accounts map to
+	// 0x40000 + RID. On Windows, compat uses the local machine's account
+	// domain SID, returned by
+	// LsaQueryInformationPolicy(PolicyAccountDomainInformation).
+	Primary SID
+	// Secondary is the domain SID that FromPOSIXID returns accounts in for
+	// IDs from 0x30000 to 0x3ffff, as ToPOSIXID maps the accounts in every
+	// domain, other than Primary, to that range. On Windows, compat uses the
+	// current user's domain, if it isn't Primary.
+	Secondary SID
+}
+
+// ToPOSIXID returns the POSIX user, or group, ID that sid maps to.
+// If sid has no mapping, the error wraps ErrUnmapped.
+func (m Mapper) ToPOSIXID(sid SID) (int, error) {
+	err := sid.Validate()
+	if err != nil {
+		return -1, err
+	}
+
+	subs := sid.SubAuthorities
+
+	switch {
+	case sid.Authority == WorldAuthority && len(subs) == 1 && subs[0] == 0:
+		return EveryoneID, nil
+	case sid.Authority != NTAuthority || len(subs) < 2:
+		// unmapped
+	case subs[0] == logonIDs:
+		return CurrentSessionID, nil
+	case subs[0] == builtinDomain:
+		return builtinOffset + int(subs[len(subs)-1]), nil
+	case subs[0] == ntNonUnique:
+		rid := int(subs[len(subs)-1])
+
+		if !m.Primary.IsZero() && sid.InDomain(m.Primary) {
+			return primaryOffset + rid, nil
+		}
+
+		return secondaryOffset + rid, nil
+	}
+
+	return -1, unmappedSIDError(sid)
+}
+
+// FromPOSIXID returns the SID that maps to the POSIX user, or group, ID.
+// As the mapping isn't one to one, 0x30201 maps to Everyone, and not to
+// RID 513 in the Secondary domain, and IDs from 0x30000 to 0x3ffff are
+// unmapped if Secondary is the zero SID. Logon SIDs aren't returned, nor are
+// accounts with RIDs of 0x10000, or more, as ToPOSIXID maps them outside
+// their range.
+// If id has no mapping, the error wraps ErrUnmapped.
+func (m Mapper) FromPOSIXID(id int) (SID, error) {
+	switch {
+	case id == EveryoneID:
+		return New(WorldAuthority, 0), nil
+	case id >= builtinOffset && id < builtinOffset+rangeSize:
+		return New(NTAuthority, builtinDomain, uint32(id-builtinOffset)), nil
+	case id >= secondaryOffset && id < secondaryOffset+rangeSize:
+		if !m.Secondary.IsZero() {
+			return m.Secondary.Append(uint32(id - secondaryOffset)), nil
+		}
+	case id >= primaryOffset && id < primaryOffset+rangeSize:
+		if !m.Primary.IsZero() {
+			return m.Primary.Append(uint32(id - primaryOffset)), nil
+		}
+	}
+
+	return SID{}, fmt.Errorf("%w: %d", ErrUnmapped, id)
+}
+
+func unmappedSIDError(sid SID) error {
+	return fmt.Errorf("%w: %v", ErrUnmapped, sid)
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package sid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rasa/compat/sid"
+)
+
+const (
+	primaryDomain   = "S-1-5-21-2870596346-3396893541-2633815437"
+	secondaryDomain = "S-1-5-21-1004336348-1177238915-682003330"
+	otherDomain     = "S-1-5-21-1-2-3"
+)
+
+var mapper = sid.Mapper{
+	Primary:   sid.MustParse(primaryDomain),
+	Secondary: sid.MustParse(secondaryDomain),
+}
+
+var mapperTests = []struct {
+	name     string
+	sid      string
+	id       int
+	oneToOne bool // FromPOSIXID(id) returns sid
+}{
+	{"Everyone", "S-1-1-0", 0x30201, true},
+	{"CurrentSession", "S-1-5-5-0-123456", 0xfff, false},
+	{"BUILTIN", "S-1-5-32-0", 0x20000, true},
+	{"Administrators", "S-1-5-32-544", 0x20220, true},
+	{"Users", "S-1-5-32-545", 0x20221, true},
+	{"BUILTIN (last)", "S-1-5-32-65535", 0x2ffff, true},
+	{"Administrator (primary)", primaryDomain + "-500", 0x401f4, true},
+	{"None (primary)", primaryDomain + "-513", 0x40201, true},
+	{"user (primary)", primaryDomain + "-1001", 0x403e9, true},
+	{"Domain Users (secondary)", secondaryDomain + "-513", 0x30201, false},
+	{"user (secondary)", secondaryDomain + "-1106", 0x30452, true},
+	{"user (other)", otherDomain + "-1106", 0x30452, false},
+}
+
+func TestMapperToPOSIXID(t *testing.T) {
+	for _, tt := range mapperTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mapper.ToPOSIXID(sid.MustParse(tt.sid))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.id {
+				t.Fatalf("ToPOSIXID(%v): got 0x%x (%d), want 0x%x (%d)", tt.sid, got, got, tt.id, tt.id)
+			}
+		})
+	}
+}
+
+func TestMapperFromPOSIXID(t *testing.T) {
+	for _, tt := range mapperTests {
+		if !tt.oneToOne {
+			continue
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mapper.FromPOSIXID(tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.String() != tt.sid {
+				t.Fatalf("FromPOSIXID(0x%x): got %v, want %v", tt.id, got, tt.sid)
+			}
+		})
+	}
+}
+
+func TestMapperRoundTrip(t *testing.T) {
+	ranges := []struct {
+		name     string
+		min, max int
+	}{
+		{"BUILTIN", 0x20000, 0x2ffff},
+		{"secondary", 0x30000, 0x3ffff},
+		{"primary", 0x40000, 0x4ffff},
+	}
+
+	for _, r := range ranges {
+		t.Run(r.name, func(t *testing.T) {
+			for id := r.min; id <= r.max; id++ {
+				if id == sid.EveryoneID {
+					continue
+				}
+
+				s, err := mapper.FromPOSIXID(id)
+				if err != nil {
+					t.Fatalf("FromPOSIXID(0x%x): %v", id, err)
+				}
+
+				got, err := mapper.ToPOSIXID(s)
+				if err != nil {
+					t.Fatalf("ToPOSIXID(%v): %v", s, err)
+				}
+
+				if got != id {
+					t.Fatalf("ToPOSIXID(%v): got 0x%x, want 0x%x", s, got, id)
+				}
+			}
+		})
+	}
+}
+
+func TestMapperBinarySID(t *testing.T) {
+	for _, tt := range mapperTests {
+		s, err := sid.FromBytes(sid.MustParse(tt.sid).Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := mapper.ToPOSIXID(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.id {
+			t.Fatalf("ToPOSIXID(%v): got 0x%x, want 0x%x", tt.sid, got, tt.id)
+		}
+	}
+}
+
+func TestMapperUnmapped(t *testing.T) {
+	sids := []string{
+		"S-1-5-18",    // SYSTEM
+		"S-1-5-32",    // the BUILTIN domain itself
+		"S-1-2-0",     // LOCAL
+		"S-1-16-8192", // Medium Mandatory Level
+		"S-1-22-1-1000",
+	}
+
+	for _, s := range sids {
+		_, err := mapper.ToPOSIXID(sid.MustParse(s))
+		if !errors.Is(err, sid.ErrUnmapped) {
+			t.Fatalf("ToPOSIXID(%v): got %v, want %v", s, err, sid.ErrUnmapped)
+		}
+	}
+
+	for _, id := range []int{-1, 0, 18, 544, 0xfff, 0x1ffff, 0x50000} {
+		_, err := mapper.FromPOSIXID(id)
+		if !errors.Is(err, sid.ErrUnmapped) {
+			t.Fatalf("FromPOSIXID(0x%x): got %v, want %v", id, err, sid.ErrUnmapped)
+		}
+	}
+}
+
+func TestMapperNoDomain(t *testing.T) {
+	var m sid.Mapper
+
+	got, err := m.ToPOSIXID(sid.MustParse(primaryDomain + "-1001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 0x303e9 {
+		t.Fatalf("ToPOSIXID(): got 0x%x, want 0x%x", got, 0x303e9)
+	}
+
+	for _, id := range []int{0x303e9, 0x403e9} {
+		_, err = m.FromPOSIXID(id)
+		if !errors.Is(err, sid.ErrUnmapped) {
+			t.Fatalf("FromPOSIXID(0x%x): got %v, want %v", id, err, sid.ErrUnmapped)
+		}
+	}
+}
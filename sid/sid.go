@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+// Package sid parses, and formats, Windows security identifiers (SIDs), and
+// maps them to, and from, POSIX user and group IDs, the way compat does on
+// Windows. It builds on every OS, so SIDs found in NTFS images, SMB metadata,
+// or archives created on Windows, can be interpreted anywhere.
+package sid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Revision is the only SID revision in use.
+	Revision = 1
+	// MaxSubAuthorities is the maximum number of subauthorities in a SID.
+	MaxSubAuthorities = 15
+
+	maxAuthority = 1<<48 - 1
+	headerLen    = 8
+)
+
+// ErrInvalidSID is returned when a SID string, or binary SID, is malformed.
+var ErrInvalidSID = errors.New("invalid SID")
+
+// A SID is a Windows security identifier, such as S-1-5-32-544.
+type SID struct {
+	Revision       uint8
+	Authority      uint64 // identifier authority, a 48-bit value
+	SubAuthorities []uint32
+}
+
+// New returns a revision 1 SID with the authority, and subauthorities.
+func New(authority uint64, subAuthorities ...uint32) SID {
+	return SID{
+		Revision:       Revision,
+		Authority:      authority,
+		SubAuthorities: subAuthorities,
+	}
+}
+
+// Parse parses a SID string, such as S-1-5-32-544. The authority can be
+// decimal, or hexadecimal with a 0x prefix, as ConvertStringSidToSid accepts.
+func Parse(s string) (SID, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) < 3 || !strings.EqualFold(parts[0], "S") {
+		return SID{}, parseError(s)
+	}
+
+	rev, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return SID{}, parseError(s)
+	}
+
+	auth, err := strconv.ParseUint(parts[2], 0, 64)
+	if err != nil || auth > maxAuthority {
+		return SID{}, parseError(s)
+	}
+
+	sid := SID{
+		Revision:  uint8(rev),
+		Authority: auth,
+	}
+
+	for _, p := range parts[3:] {
+		sub, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return SID{}, parseError(s)
+		}
+
+		sid.SubAuthorities = append(sid.SubAuthorities, uint32(sub))
+	}
+
+	err = sid.Validate()
+	if err != nil {
+		return SID{}, err
+	}
+
+	return sid, nil
+}
+
+// MustParse is like Parse, but panics if s cannot be parsed.
+func MustParse(s string) SID {
+	sid, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return sid
+}
+
+// FromBytes decodes a binary SID, as stored in a security descriptor.
+// Trailing bytes are ignored.
+func FromBytes(b []byte) (SID, error) {
+	if len(b) < headerLen {
+		return SID{}, fmt.Errorf("%w: %d bytes", ErrInvalidSID, len(b))
+	}
+
+	count := int(b[1])
+	if count > MaxSubAuthorities || len(b) < headerLen+4*count {
+		return SID{}, fmt.Errorf("%w: %d subauthorities in %d bytes", ErrInvalidSID, count, len(b))
+	}
+
+	var auth [8]byte
+
+	copy(auth[2:], b[2:headerLen])
+
+	sid := SID{
+		Revision:  b[0],
+		Authority: binary.BigEndian.Uint64(auth[:]),
+	}
+
+	for i := range count {
+		sid.SubAuthorities = append(sid.SubAuthorities, binary.LittleEndian.Uint32(b[headerLen+4*i:]))
+	}
+
+	err := sid.Validate()
+	if err != nil {
+		return SID{}, err
+	}
+
+	return sid, nil
+}
+
+// Bytes returns the binary form of the SID, as stored in a security
+// descriptor.
+func (s SID) Bytes() []byte {
+	b := make([]byte, headerLen+4*len(s.SubAuthorities))
+	b[0] = s.Revision
+	b[1] = byte(len(s.SubAuthorities))
+
+	var auth [8]byte
+
+	binary.BigEndian.PutUint64(auth[:], s.Authority)
+	copy(b[2:headerLen], auth[2:])
+
+	for i, sub := range s.SubAuthorities {
+		binary.LittleEndian.PutUint32(b[headerLen+4*i:], sub)
+	}
+
+	return b
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+func (s SID) MarshalBinary() ([]byte, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Bytes(), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+func (s *SID) UnmarshalBinary(b []byte) error {
+	sid, err := FromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	*s = sid
+
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (s SID) MarshalText() ([]byte, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (s *SID) UnmarshalText(b []byte) error {
+	sid, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+
+	*s = sid
+
+	return nil
+}
+
+// String returns the SID in its S-R-I-S-S... form. Like
+// ConvertSidToStringSid, authorities of 2^32 or more are formatted in
+// hexadecimal.
+func (s SID) String() string {
+	var b strings.Builder
+
+	b.WriteString("S-")
+	b.WriteString(strconv.FormatUint(uint64(s.Revision), 10))
+	b.WriteByte('-')
+
+	if s.Authority >= 1<<32 {
+		fmt.Fprintf(&b, "0x%012X", s.Authority)
+	} else {
+		b.WriteString(strconv.FormatUint(s.Authority, 10))
+	}
+
+	for _, sub := range s.SubAuthorities {
+		b.WriteByte('-')
+		b.WriteString(strconv.FormatUint(uint64(sub), 10))
+	}
+
+	return b.String()
+}
+
+// Validate returns an error wrapping ErrInvalidSID if the SID's revision, or
+// number of subauthorities, is invalid.
+func (s SID) Validate() error {
+	switch {
+	case s.Revision != Revision:
+		return fmt.Errorf("%w: revision %d", ErrInvalidSID, s.Revision)
+	case len(s.SubAuthorities) > MaxSubAuthorities:
+		return fmt.Errorf("%w: %d subauthorities", ErrInvalidSID, len(s.SubAuthorities))
+	case s.Authority > maxAuthority:
+		return fmt.Errorf("%w: authority %d", ErrInvalidSID, s.Authority)
+	}
+
+	return nil
+}
+
+// IsZero returns true if the SID is the zero value.
+func (s SID) IsZero() bool {
+	return s.Revision == 0 && s.Authority == 0 && len(s.SubAuthorities) == 0
+}
+
+// Equal returns true if the SIDs are the same.
+func (s SID) Equal(other SID) bool {
+	if s.Revision != other.Revision || s.Authority != other.Authority ||
+		len(s.SubAuthorities) != len(other.SubAuthorities) {
+		return false
+	}
+
+	for i, sub := range s.SubAuthorities {
+		if other.SubAuthorities[i] != sub {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RID returns the SID's relative identifier, its last subauthority.
+func (s SID) RID() (uint32, error) {
+	if len(s.SubAuthorities) == 0 {
+		return 0, fmt.Errorf("%w: no subauthorities in %v", ErrInvalidSID, s)
+	}
+
+	return s.SubAuthorities[len(s.SubAuthorities)-1], nil
+}
+
+// Domain returns the SID without its relative identifier, such as the
+// domain SID of an account SID.
+func (s SID) Domain() SID {
+	d := s
+	if len(d.SubAuthorities) > 0 {
+		d.SubAuthorities = d.SubAuthorities[:len(d.SubAuthorities)-1]
+	}
+
+	return d
+}
+
+// Append returns the SID with rid appended, such as an account SID built
+// from its domain SID.
+func (s SID) Append(rid uint32) SID {
+	d := s
+	d.SubAuthorities = append(append(make([]uint32, 0, len(s.SubAuthorities)+1), s.SubAuthorities...), rid)
+
+	return d
+}
+
+// InDomain returns true if the SID is an account in the domain.
+func (s SID) InDomain(domain SID) bool {
+	return len(s.SubAuthorities) == len(domain.SubAuthorities)+1 && s.Domain().Equal(domain)
+}
+
+func parseError(s string) error {
+	return fmt.Errorf("%w: %q", ErrInvalidSID, s)
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package sid_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rasa/compat/sid"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want sid.SID
+		str  string
+	}{
+		{"S-1-1-0", sid.New(1, 0), "S-1-1-0"},
+		{"S-1-5-18", sid.New(5, 18), "S-1-5-18"},
+		{"S-1-5-32-544", sid.New(5, 32, 544), "S-1-5-32-544"},
+		{"S-1-5-21-1004336348-1177238915-682003330-512", sid.New(5, 21, 1004336348, 1177238915, 682003330, 512), ""},
+		{"s-1-5-18", sid.New(5, 18), "S-1-5-18"},
+		{"S-1-0x5-18", sid.New(5, 18), "S-1-5-18"},
+		{"S-1-0x123456789ABC-1", sid.New(0x123456789abc, 1), "S-1-0x123456789ABC-1"},
+		{"S-1-5", sid.New(5), "S-1-5"},
+	}
+
+	for _, tt := range tests {
+		got, err := sid.Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+
+		if !got.Equal(tt.want) {
+			t.Fatalf("Parse(%q): got %#v, want %#v", tt.in, got, tt.want)
+		}
+
+		want := tt.str
+		if want == "" {
+			want = tt.in
+		}
+
+		if got.String() != want {
+			t.Fatalf("Parse(%q).String(): got %q, want %q", tt.in, got.String(), want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"S",
+		"S-1",
+		"X-1-5-18",
+		"S-2-5-18",
+		"S-0-5-18",
+		"S-1-5-x",
+		"S-1-5-4294967296",
+		"S-1-0x1000000000000-1",
+		"S-1-5-1-2-3-4-5-6-7-8-9-10-11-12-13-14-15-16",
+	} {
+		_, err := sid.Parse(in)
+		if !errors.Is(err, sid.ErrInvalidSID) {
+			t.Fatalf("Parse(%q): got %v, want %v", in, err, sid.ErrInvalidSID)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	// S-1-5-32-544, from the GetRID test in the compat package.
+	want := []byte{
+		1,                // Revision
+		2,                // SubAuthorityCount
+		0, 0, 0, 0, 0, 5, // IdentifierAuthority = SECURITY_NT_AUTHORITY
+		32, 0, 0, 0, // SubAuthority[0] = SECURITY_BUILTIN_DOMAIN_RID
+		0x20, 0x02, 0, 0, // SubAuthority[1] = DOMAIN_ALIAS_RID_ADMINS
+	}
+
+	s := sid.MustParse("S-1-5-32-544")
+
+	got := s.Bytes()
+	if !bytes.Equal(got, want) {
+		t.Fatalf("Bytes(): got %v, want %v", got, want)
+	}
+
+	back, err := sid.FromBytes(append(got, 0xff))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !back.Equal(s) {
+		t.Fatalf("FromBytes(): got %v, want %v", back, s)
+	}
+}
+
+func TestFromBytesInvalid(t *testing.T) {
+	for i, b := range [][]byte{
+		nil,
+		{1, 1, 0, 0, 0, 0, 0},
+		{1, 1, 0, 0, 0, 0, 0, 5},
+		{0, 1, 0, 0, 0, 0, 0, 5, 32, 0, 0, 0},
+		{2, 1, 0, 0, 0, 0, 0, 5, 32, 0, 0, 0},
+		append([]byte{1, 16, 0, 0, 0, 0, 0, 5}, make([]byte, 4*16)...),
+	} {
+		_, err := sid.FromBytes(b)
+		if !errors.Is(err, sid.ErrInvalidSID) {
+			t.Fatalf("test %d: FromBytes(%v): got %v, want %v", i+1, b, err, sid.ErrInvalidSID)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	s := sid.MustParse("S-1-5-21-1-2-3-1001")
+
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got sid.SID
+
+	err = got.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(s) {
+		t.Fatalf("UnmarshalBinary(): got %v, want %v", got, s)
+	}
+
+	text, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = got.UnmarshalText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(s) {
+		t.Fatalf("UnmarshalText(): got %v, want %v", got, s)
+	}
+
+	_, err = sid.SID{}.MarshalBinary()
+	if !errors.Is(err, sid.ErrInvalidSID) {
+		t.Fatalf("MarshalBinary(): got %v, want %v", err, sid.ErrInvalidSID)
+	}
+}
+
+func TestRIDAndDomain(t *testing.T) {
+	s := sid.MustParse("S-1-5-21-1-2-3-1001")
+
+	rid, err := s.RID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rid != 1001 {
+		t.Fatalf("RID(): got %d, want 1001", rid)
+	}
+
+	domain := sid.MustParse("S-1-5-21-1-2-3")
+	if !s.Domain().Equal(domain) {
+		t.Fatalf("Domain(): got %v, want %v", s.Domain(), domain)
+	}
+
+	if !s.InDomain(domain) {
+		t.Fatalf("InDomain(%v): got false, want true", domain)
+	}
+
+	if !domain.Append(1001).Equal(s) {
+		t.Fatalf("Append(1001): got %v, want %v", domain.Append(1001), s)
+	}
+
+	if s.InDomain(sid.MustParse("S-1-5-21-1-2-4")) {
+		t.Fatal("InDomain(S-1-5-21-1-2-4): got true, want false")
+	}
+
+	_, err = sid.MustParse("S-1-5").RID()
+	if !errors.Is(err, sid.ErrInvalidSID) {
+		t.Fatalf("RID(): got %v, want %v", err, sid.ErrInvalidSID)
+	}
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// A Snapshot is a plain copy of every field of a [FileInfo]. It can be
+// marshaled to, and unmarshaled from, JSON, so file metadata can be stored,
+// and reloaded later, or on another OS. A Snapshot implements [FileInfo].
+type Snapshot struct {
+	name   string
+	size   int64
+	mode   os.FileMode
+	mtime  time.Time
+	atime  time.Time
+	btime  time.Time
+	ctime  time.Time
+	links  uint
+	uid    int
+	gid    int
+	user   string
+	group  string
+	partID uint64
+	fileID uint64
+	attrs  Attribute
+	mntID  uint64
+	caps   *FileCaps
+	err    error // not marshaled
+	errs   error // not marshaled
+}
+
+// NewSnapshot returns a Snapshot holding every field of fi. The lazily
+// populated fields, such as User() and BTime(), are looked up, so any errors
+// are available via the Snapshot's Error() and Errors() functions.
+func NewSnapshot(fi FileInfo) *Snapshot {
+	return &Snapshot{
+		name:   fi.Name(),
+		size:   fi.Size(),
+		mode:   fi.Mode(),
+		mtime:  fi.ModTime(),
+		atime:  fi.ATime(),
+		btime:  fi.BTime(),
+		ctime:  fi.CTime(),
+		links:  fi.Links(),
+		uid:    fi.UID(),
+		gid:    fi.GID(),
+		user:   fi.User(),
+		group:  fi.Group(),
+		partID: fi.PartitionID(),
+		fileID: fi.FileID(),
+		attrs:  fi.Attributes(),
+		mntID:  fi.MountID(),
+		caps:   fi.Capabilities(),
+		err:    fi.Error(),
+		errs:   fi.Errors(),
+	}
+}
+
+func (s *Snapshot) Name() string       { return s.name }
+func (s *Snapshot) Size() int64        { return s.size }
+func (s *Snapshot) Mode() os.FileMode  { return s.mode }
+func (s *Snapshot) ModTime() time.Time { return s.mtime }
+func (s *Snapshot) IsDir() bool        { return s.mode.IsDir() }
+
+// Sys returns nil, as a Snapshot has no underlying data source.
+func (s *Snapshot) Sys() any { return nil }
+
+func (s *Snapshot) ATime() time.Time      { return s.atime }
+func (s *Snapshot) BTime() time.Time      { return s.btime }
+func (s *Snapshot) CTime() time.Time      { return s.ctime }
+func (s *Snapshot) MTime() time.Time      { return s.mtime } // duplicates ModTime
+func (s *Snapshot) Links() uint           { return s.links }
+func (s *Snapshot) UID() int              { return s.uid }
+func (s *Snapshot) GID() int              { return s.gid }
+func (s *Snapshot) User() string          { return s.user }
+func (s *Snapshot) Group() string         { return s.group }
+func (s *Snapshot) PartitionID() uint64   { return s.partID }
+func (s *Snapshot) FileID() uint64        { return s.fileID }
+func (s *Snapshot) Attributes() Attribute { return s.attrs }
+func (s *Snapshot) MountID() uint64       { return s.mntID }
+
+func (s *Snapshot) Capabilities() *FileCaps { return s.caps.clone() }
+
+// Error returns the last lookup error of the FileInfo the Snapshot was
+// created from, or nil. Errors are not marshaled.
+func (s *Snapshot) Error() error { return s.err }
+
+// Errors returns every lookup error of the FileInfo the Snapshot was
+// created from, joined, or nil. Errors are not marshaled.
+func (s *Snapshot) Errors() error { return s.errs }
+
+func (s *Snapshot) String() string {
+	return formatStat(s)
+}
+
+func (s *Snapshot) Info() (os.FileInfo, error) {
+	return os.FileInfo(s), s.Error()
+}
+
+// snapshotJSON is the JSON representation of a Snapshot. The names, and the
+// order, of its fields are part of the API, so must not change.
+type snapshotJSON struct {
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	Mode        uint32    `json:"mode"`
+	ModTime     time.Time `json:"mtime"`
+	ATime       time.Time `json:"atime"`
+	BTime       time.Time `json:"btime"`
+	CTime       time.Time `json:"ctime"`
+	Links       uint      `json:"links"`
+	UID         int       `json:"uid"`
+	GID         int       `json:"gid"`
+	User        string    `json:"user"`
+	Group       string    `json:"group"`
+	PartitionID uint64    `json:"partitionID"`
+	FileID      uint64    `json:"fileID"`
+	Attributes  uint64    `json:"attributes"`
+	MountID     uint64    `json:"mountID"`
+	Caps        []byte    `json:"caps,omitempty"`
+}
+
+// MarshalJSON implements [json.Marshaler]. The mode is marshaled as its
+// os.FileMode bits, which are the same on every OS, and times are marshaled
+// in RFC 3339 format, with nanoseconds. The zero time means unsupported.
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	var caps []byte
+
+	if s.caps != nil {
+		var err error
+
+		caps, err = s.caps.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(snapshotJSON{
+		Name:        s.name,
+		Size:        s.size,
+		Mode:        uint32(s.mode),
+		ModTime:     s.mtime,
+		ATime:       s.atime,
+		BTime:       s.btime,
+		CTime:       s.ctime,
+		Links:       s.links,
+		UID:         s.uid,
+		GID:         s.gid,
+		User:        s.user,
+		Group:       s.group,
+		PartitionID: s.partID,
+		FileID:      s.fileID,
+		Attributes:  uint64(s.attrs),
+		MountID:     s.mntID,
+		Caps:        caps,
+	})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. Missing uid and gid values
+// are set to [UnknownID].
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	v := snapshotJSON{UID: UnknownID, GID: UnknownID}
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	*s = Snapshot{
+		name:   v.Name,
+		size:   v.Size,
+		mode:   os.FileMode(v.Mode),
+		mtime:  v.ModTime,
+		atime:  v.ATime,
+		btime:  v.BTime,
+		ctime:  v.CTime,
+		links:  v.Links,
+		uid:    v.UID,
+		gid:    v.GID,
+		user:   v.User,
+		group:  v.Group,
+		partID: v.PartitionID,
+		fileID: v.FileID,
+		attrs:  Attribute(v.Attributes),
+		mntID:  v.MountID,
+	}
+
+	if len(v.Caps) > 0 {
+		s.caps = &FileCaps{}
+
+		err = s.caps.UnmarshalBinary(v.Caps)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rasa/compat"
+)
+
+var _ compat.FileInfo = (*compat.Snapshot)(nil)
+
+const snapshotGolden = `{"name":"hello.txt","size":5,"mode":420,` +
+	`"mtime":"2026-01-02T03:04:05.000000006Z","atime":"2026-01-03T03:04:05Z",` +
+	`"btime":"0001-01-01T00:00:00Z","ctime":"2026-01-04T03:04:05Z",` +
+	`"links":2,"uid":1000,"gid":100,"user":"alice","group":"users",` +
+	`"partitionID":66305,"fileID":1234,"attributes":17,"mountID":29}`
+
+func TestSnapshotFromStat(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := compat.NewSnapshot(fi)
+
+	compareFileInfos(t, s, fi)
+
+	if s.Sys() != nil {
+		t.Errorf("Sys(): got %v, want nil", s.Sys())
+	}
+
+	if got, want := s.String(), fi.String(); got != want {
+		t.Errorf("String(): got %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotJSONRoundTrip(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(compat.NewSnapshot(fi))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s compat.Snapshot
+
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compareFileInfos(t, &s, fi)
+}
+
+func TestSnapshotJSONStable(t *testing.T) {
+	var s compat.Snapshot
+
+	err := json.Unmarshal([]byte(snapshotGolden), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := s.Mode(), os.FileMode(0o644); got != want {
+		t.Errorf("Mode(): got %v, want %v", got, want)
+	}
+
+	mtime := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	if got := s.ModTime(); !got.Equal(mtime) {
+		t.Errorf("ModTime(): got %v, want %v", got, mtime)
+	}
+
+	if got := s.BTime(); !got.IsZero() {
+		t.Errorf("BTime(): got %v, want the zero time", got)
+	}
+
+	if got, want := s.Attributes(), compat.AttributeImmutable|compat.AttributeNoDump; got != want {
+		t.Errorf("Attributes(): got %v, want %v", got, want)
+	}
+
+	data, err := json.Marshal(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(data); got != snapshotGolden {
+		t.Errorf("MarshalJSON():\ngot  %v\nwant %v", got, snapshotGolden)
+	}
+}
+
+func TestSnapshotJSONMissingIDs(t *testing.T) {
+	var s compat.Snapshot
+
+	err := json.Unmarshal([]byte(`{"name":"x"}`), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.UID(); got != compat.UnknownID {
+		t.Errorf("UID(): got %v, want %v", got, compat.UnknownID)
+	}
+
+	if got := s.GID(); got != compat.UnknownID {
+		t.Errorf("GID(): got %v, want %v", got, compat.UnknownID)
+	}
+}
+
+func TestSnapshotJSONInvalid(t *testing.T) {
+	var s compat.Snapshot
+
+	err := json.Unmarshal([]byte(`{"size":"big"}`), &s)
+	if err == nil {
+		t.Fatal("UnmarshalJSON(): got nil, want an error")
+	}
+}
+
+func compareFileInfos(t *testing.T, got, want compat.FileInfo) {
+	t.Helper()
+
+	if got.Name() != want.Name() {
+		t.Errorf("Name(): got %v, want %v", got.Name(), want.Name())
+	}
+
+	if got.Size() != want.Size() {
+		t.Errorf("Size(): got %v, want %v", got.Size(), want.Size())
+	}
+
+	if got.Mode() != want.Mode() {
+		t.Errorf("Mode(): got %v, want %v", got.Mode(), want.Mode())
+	}
+
+	if got.IsDir() != want.IsDir() {
+		t.Errorf("IsDir(): got %v, want %v", got.IsDir(), want.IsDir())
+	}
+
+	times := []struct {
+		name      string
+		got, want time.Time
+	}{
+		{"ModTime()", got.ModTime(), want.ModTime()},
+		{"MTime()", got.MTime(), want.MTime()},
+		{"ATime()", got.ATime(), want.ATime()},
+		{"BTime()", got.BTime(), want.BTime()},
+		{"CTime()", got.CTime(), want.CTime()},
+	}
+	for _, tt := range times {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got.Links() != want.Links() {
+		t.Errorf("Links(): got %v, want %v", got.Links(), want.Links())
+	}
+
+	if got.UID() != want.UID() {
+		t.Errorf("UID(): got %v, want %v", got.UID(), want.UID())
+	}
+
+	if got.GID() != want.GID() {
+		t.Errorf("GID(): got %v, want %v", got.GID(), want.GID())
+	}
+
+	if got.User() != want.User() {
+		t.Errorf("User(): got %v, want %v", got.User(), want.User())
+	}
+
+	if got.Group() != want.Group() {
+		t.Errorf("Group(): got %v, want %v", got.Group(), want.Group())
+	}
+
+	if got.PartitionID() != want.PartitionID() {
+		t.Errorf("PartitionID(): got %v, want %v", got.PartitionID(), want.PartitionID())
+	}
+
+	if got.FileID() != want.FileID() {
+		t.Errorf("FileID(): got %v, want %v", got.FileID(), want.FileID())
+	}
+
+	if got.Attributes() != want.Attributes() {
+		t.Errorf("Attributes(): got %v, want %v", got.Attributes(), want.Attributes())
+	}
+
+	if got.MountID() != want.MountID() {
+		t.Errorf("MountID(): got %v, want %v", got.MountID(), want.MountID())
+	}
+}
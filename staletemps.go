@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CleanupStaleTemps removes the temporary files, left in dir by atomic writes
+// that didn't complete, that were last modified more than olderThan ago.
+// Only regular files whose names match the temp pattern ("~*.tmp", or the
+// pattern passed via WithTempPattern), with a decimal random part, as
+// CreateTemp generates, are removed. golang.SetRandom enforces a decimal
+// random part, so names from a replaced generator are removed, too.
+// It returns the names of the files it removed. It removes everything it can,
+// and returns every error it encounters, joined.
+func CleanupStaleTemps(dir string, olderThan time.Duration, opts ...Option) ([]string, error) {
+	fopts := buildOptions(opts...)
+
+	pattern := tempPattern(&fopts)
+
+	prefix, suffix, err := tempPrefixAndSuffix(pattern)
+	if err != nil {
+		return nil, createTempError(pattern, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+
+	var (
+		removed []string
+		errs    []error
+	)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !isTempName(entry.Name(), prefix, suffix) {
+			continue
+		}
+
+		fi, err := entry.Info()
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		if !fi.ModTime().Before(cutoff) {
+			continue
+		}
+
+		name := filepath.Join(dir, entry.Name())
+
+		// the file may have been created read-only.
+		_ = Chmod(name, CreateTempPerm) // 0o600
+
+		err = Remove(name, WithDurability(fopts.durable))
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		removed = append(removed, name)
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+// isTempName returns true if name is prefix, followed by one or more
+// decimal digits, followed by suffix.
+func isTempName(name, prefix, suffix string) bool {
+	if len(name) <= len(prefix)+len(suffix) ||
+		!strings.HasPrefix(name, prefix) ||
+		!strings.HasSuffix(name, suffix) {
+		return false
+	}
+
+	random := name[len(prefix) : len(name)-len(suffix)]
+
+	for i := range len(random) {
+		if random[i] < '0' || random[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rasa/compat"
+	"github.com/rasa/compat/golang"
+)
+
+// sequentialRandom makes CreateTemp's names deterministic, by returning 1,
+// 2, 3, etc., as the random part.
+func sequentialRandom(t *testing.T) {
+	t.Helper()
+
+	n := 0
+
+	restore := golang.SetRandom(func() string {
+		n++
+
+		return strconv.Itoa(n)
+	})
+	t.Cleanup(restore)
+}
+
+// createAgedFile creates the named file in dir, last modified age ago.
+func createAgedFile(t *testing.T, dir, name string, age time.Duration) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	err := os.WriteFile(path, helloBytes, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().Add(-age)
+
+	err = os.Chtimes(path, mtime, mtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestCreateTempSetRandom(t *testing.T) {
+	sequentialRandom(t)
+
+	dir := tempDir(t)
+
+	for _, want := range []string{"~1.tmp", "~2.tmp"} {
+		f, err := compat.CreateTemp(dir, "~*.tmp")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = f.Close()
+
+		if got := filepath.Base(f.Name()); got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+
+	// a name that exists is skipped, as it is with random names.
+	createAgedFile(t, dir, "~3.tmp", 0)
+
+	f, err := compat.CreateTemp(dir, "~*.tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	if got := filepath.Base(f.Name()); got != "~4.tmp" {
+		t.Fatalf("got %q, want %q", got, "~4.tmp")
+	}
+}
+
+func TestSetRandomNotDecimal(t *testing.T) {
+	restore := golang.SetRandom(func() string { return "abc" })
+	t.Cleanup(restore)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("CreateTemp(): got no panic, want a panic")
+		}
+	}()
+
+	f, _ := compat.CreateTemp(tempDir(t), "~*.tmp")
+	if f != nil {
+		_ = f.Close()
+	}
+}
+
+func TestCleanupStaleTemps(t *testing.T) {
+	dir := tempDir(t)
+
+	stale := createAgedFile(t, dir, "~123.tmp", 2*time.Hour)
+	createAgedFile(t, dir, "~456.tmp", 0)           // too new
+	createAgedFile(t, dir, "~abc.tmp", 2*time.Hour) // not a random part
+	createAgedFile(t, dir, "~.tmp", 2*time.Hour)    // no random part
+	createAgedFile(t, dir, "x123.tmp", 2*time.Hour) // wrong prefix
+	createAgedFile(t, dir, "~123.txt", 2*time.Hour) // wrong suffix
+
+	err := os.Mkdir(filepath.Join(dir, "~789.tmp"), perm700) // not a file
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := compat.CleanupStaleTemps(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(removed, []string{stale}) {
+		t.Fatalf("got %q, want %q", removed, []string{stale})
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 6 {
+		t.Fatalf("got %d entries, want 6", len(entries))
+	}
+}
+
+func TestCleanupStaleTempsWithTempPattern(t *testing.T) {
+	dir := tempDir(t)
+
+	stale := createAgedFile(t, dir, ".compat-42.part", 2*time.Hour)
+	createAgedFile(t, dir, "~42.tmp", 2*time.Hour)
+
+	removed, err := compat.CleanupStaleTemps(dir, time.Hour, compat.WithTempPattern(".compat-*.part"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(removed, []string{stale}) {
+		t.Fatalf("got %q, want %q", removed, []string{stale})
+	}
+}
+
+func TestCleanupStaleTempsGlobalTempPattern(t *testing.T) {
+	dir := tempDir(t)
+
+	stale := createAgedFile(t, dir, ".compat-42.part", 2*time.Hour)
+	createAgedFile(t, dir, "~42.tmp", 2*time.Hour)
+
+	compat.SetOptions(compat.WithTempPattern(".compat-*.part"))
+	t.Cleanup(func() { compat.SetOptions(compat.WithTempPattern("")) })
+
+	removed, err := compat.CleanupStaleTemps(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(removed, []string{stale}) {
+		t.Fatalf("got %q, want %q", removed, []string{stale})
+	}
+
+	// atomic writes use the same pattern, so CleanupStaleTemps finds them.
+	// On Linux, the temporary file may be unnamed, and not listed.
+	w, err := compat.NewAtomicWriter(filepath.Join(dir, "file.txt"), perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		if entry.Name() != "~42.tmp" && !strings.HasPrefix(entry.Name(), ".compat-") {
+			t.Fatalf("got %q, want a .compat-*.part file", entry.Name())
+		}
+	}
+}
+
+func TestCleanupStaleTempsCreateTemp(t *testing.T) {
+	sequentialRandom(t)
+
+	dir := tempDir(t)
+
+	// simulate a crash, by leaving a temporary file behind.
+	f, err := compat.CreateTemp(dir, "~*.part")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = f.Close()
+
+	// a negative age matches files modified up to an hour in the future.
+	removed, err := compat.CleanupStaleTemps(dir, -time.Hour, compat.WithTempPattern("~*.part"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join(dir, "~1.part")}
+	if !slices.Equal(removed, want) {
+		t.Fatalf("got %q, want %q", removed, want)
+	}
+}
+
+func TestCleanupStaleTempsInvalid(t *testing.T) {
+	_, err := compat.CleanupStaleTemps(tempDir(t), time.Hour, compat.WithTempPattern("a/*"))
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+
+	_, err = compat.CleanupStaleTemps(invalidName, time.Hour)
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+
+	_, err = compat.NewAtomicWriter(tempName(t), perm600, compat.WithTempPattern("a/*"))
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+}
+
+func TestCleanupStaleTempsNoPrefixOrSuffix(t *testing.T) {
+	dir := tempDir(t)
+	name := createAgedFile(t, dir, "123", 2*time.Hour)
+
+	_, err := compat.CleanupStaleTemps(dir, time.Hour, compat.WithTempPattern("*"))
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+
+	_, err = os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.NewAtomicWriter(filepath.Join(dir, "file.txt"), perm600, compat.WithTempPattern("*"))
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+}
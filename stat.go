@@ -4,6 +4,7 @@
 package compat
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -31,6 +32,8 @@ const (
 )
 
 // A FileInfo describes a file and is returned by [Stat].
+// The FileInfo values returned by this package are safe for concurrent use
+// by multiple goroutines.
 // See https://github.com/golang/go/blob/ad7a6f81/src/io/fs/fs.go#L158
 type FileInfo interface { //nolang:interfacebloat
 	Name() string       // base name of the file
@@ -51,9 +54,15 @@ type FileInfo interface { //nolang:interfacebloat
 	Group() string       // group name, or "" if an error or unsupported
 	PartitionID() uint64 // unique disk partition ID
 	FileID() uint64      // unique file ID (on a specific partition)
-	Error() error        // error result of the last system call that failed
+	Error() error        // *FieldError result of the last lookup that failed
 	String() string
 	Info() (os.FileInfo, error)
+
+	Attributes() Attribute // per-file kernel attributes, or 0 if unsupported
+	MountID() uint64       // mount ID, or 0 if unsupported
+	Errors() error         // every *FieldError that occurred, joined
+
+	Capabilities() *FileCaps // Linux file capabilities, or nil if none or unsupported
 }
 
 func (fs *fileStat) Name() string       { return fs.name }
@@ -68,24 +77,74 @@ func (fs *fileStat) MTime() time.Time    { return fs.mtime } // duplicates ModTi
 func (fs *fileStat) Links() uint         { return fs.links }
 func (fs *fileStat) PartitionID() uint64 { return fs.partID }
 func (fs *fileStat) FileID() uint64      { return fs.fileID }
-func (fs *fileStat) Error() error        { return fs.err }
+
+// Error returns the error result of the last lazy lookup that failed.
+// The error is of type [*FieldError].
+func (fs *fileStat) Error() error {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	return fs.err
+}
+
+// Errors returns every lazy lookup that failed, joined by [errors.Join],
+// or nil if none failed. Each error is of type [*FieldError], so a caller can
+// tell, say, a failed BTime() lookup apart from a failed User() lookup:
+//
+//	if joined, ok := fi.Errors().(interface{ Unwrap() []error }); ok {
+//		for _, err := range joined.Unwrap() {
+//			var fe *compat.FieldError
+//			if errors.As(err, &fe) && fe.Field == compat.FieldUser {
+//				// ...
+//			}
+//		}
+//	}
+func (fs *fileStat) Errors() error {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	return errors.Join(fs.errs...)
+}
+
+// setUnsupported records that the filesystem doesn't support field. Unlike
+// setError, it doesn't set the error returned by Error(), as the lookup
+// didn't fail, so it is only reported by Errors().
+// The caller must hold fs.mux.
+func (fs *fileStat) setUnsupported(field, op string) {
+	fs.errs = append(fs.errs, &FieldError{Field: field, Op: op, Err: &UnsupportedError{Op: op + " " + field}})
+}
+
+// setError records a failed lookup of field. The caller must hold fs.mux.
+func (fs *fileStat) setError(field, op string, err error) {
+	fe := &FieldError{Field: field, Op: op, Err: err}
+	fs.err = fe
+	fs.errs = append(fs.errs, fe)
+}
+
+func (fs *fileStat) Attributes() Attribute { return fs.attrs }
+func (fs *fileStat) MountID() uint64       { return fs.mntID }
 
 func (fs *fileStat) String() string {
+	return formatStat(fs)
+}
+
+// formatStat returns every field of fi, one per line.
+func formatStat(fi FileInfo) string {
 	var builder strings.Builder
 
-	fmt.Fprintf(&builder, "Name:   %v\n", fs.Name())
-	fmt.Fprintf(&builder, "Size:   %v\n", fs.Size())
-	fmt.Fprintf(&builder, "Mode:   0o%o (%v)\n", fs.Mode(), fs.Mode())
-	fmt.Fprintf(&builder, "ModTime:%v\n", fs.ModTime())
-	fmt.Fprintf(&builder, "ATime:  %v\n", fs.ATime())
-	fmt.Fprintf(&builder, "BTime:  %v\n", fs.BTime())
-	fmt.Fprintf(&builder, "CTime:  %v\n", fs.CTime())
-	fmt.Fprintf(&builder, "IsDir:  %v\n", fs.IsDir())
-	fmt.Fprintf(&builder, "Links:  %v\n", fs.Links())
-	fmt.Fprintf(&builder, "UID:    %v (%v)\n", fs.UID(), fs.User())
-	fmt.Fprintf(&builder, "GID:    %v (%v)\n", fs.GID(), fs.Group())
-	fmt.Fprintf(&builder, "PartID: %v\n", fs.PartitionID())
-	fmt.Fprintf(&builder, "FileID: %v\n", fs.FileID())
+	fmt.Fprintf(&builder, "Name:   %v\n", fi.Name())
+	fmt.Fprintf(&builder, "Size:   %v\n", fi.Size())
+	fmt.Fprintf(&builder, "Mode:   0o%o (%v)\n", fi.Mode(), fi.Mode())
+	fmt.Fprintf(&builder, "ModTime:%v\n", fi.ModTime())
+	fmt.Fprintf(&builder, "ATime:  %v\n", fi.ATime())
+	fmt.Fprintf(&builder, "BTime:  %v\n", fi.BTime())
+	fmt.Fprintf(&builder, "CTime:  %v\n", fi.CTime())
+	fmt.Fprintf(&builder, "IsDir:  %v\n", fi.IsDir())
+	fmt.Fprintf(&builder, "Links:  %v\n", fi.Links())
+	fmt.Fprintf(&builder, "UID:    %v (%v)\n", fi.UID(), fi.User())
+	fmt.Fprintf(&builder, "GID:    %v (%v)\n", fi.GID(), fi.Group())
+	fmt.Fprintf(&builder, "PartID: %v\n", fi.PartitionID())
+	fmt.Fprintf(&builder, "FileID: %v\n", fi.FileID())
 
 	return builder.String()
 }
@@ -109,30 +168,26 @@ func Fstat(f *os.File) (FileInfo, error) {
 // named entity (such as a symbolic link or mounted folder), the returned
 // FileInfo describes the reparse point, and makes no attempt to resolve it.
 func Lstat(name string) (FileInfo, error) {
-	fi, err := os.Lstat(name)
-	if err != nil {
-		return nil, err
-	}
-
-	return stat(fi, name, false)
+	return statName(name, false)
 }
 
 // SameFile reports whether fi1 and fi2 describe the same file. For example,
 // on Unix this means that the partition (device) and inode fields of the two
 // underlying structures are identical; on other systems the decision may be
 // based on the path names.
-// SamePartition only applies to results returned by this package's [Stat].
-// It returns false in other cases.
+// SameFile compares the values returned by [KeyOf], so it applies to any
+// [FileInfo], including a [Snapshot]. It returns false if either file's
+// identity is unknown.
 func SameFile(fi1, fi2 FileInfo) bool {
-	fs1, ok1 := fi1.(*fileStat)
+	key1, ok1 := KeyOf(fi1)
 
-	fs2, ok2 := fi2.(*fileStat)
+	key2, ok2 := KeyOf(fi2)
 
 	if !ok1 || !ok2 {
 		return false
 	}
 
-	return fs1.partID == fs2.partID && fs1.fileID == fs2.fileID
+	return key1 == key2
 }
 
 // SameFiles reports whether name1 and name2 are the same file.
@@ -155,18 +210,19 @@ func SameFiles(name1, name2 string) (bool, error) {
 // partition. For example, on Unix this means that the partition (device) fields
 // of the two underlying structures are identical; on other systems
 // the decision may be based on the path names.
-// SamePartition only applies to results returned by this package's [Stat].
-// It returns false in other cases.
+// SamePartition compares the values returned by [KeyOf], so it applies to
+// any [FileInfo], including a [Snapshot]. It returns false if either file's
+// identity is unknown.
 func SamePartition(fi1, fi2 FileInfo) bool {
-	fs1, ok1 := fi1.(*fileStat)
+	key1, ok1 := KeyOf(fi1)
 
-	fs2, ok2 := fi2.(*fileStat)
+	key2, ok2 := KeyOf(fi2)
 
 	if !ok1 || !ok2 {
 		return false
 	}
 
-	return fs1.partID == fs2.partID
+	return key1.Partition == key2.Partition
 }
 
 // SamePartitions reports whether name1 and name2 are files on the same disk
@@ -189,12 +245,27 @@ func SamePartitions(name1, name2 string) (bool, error) {
 // Stat returns a [FileInfo] describing the named file.
 // If there is an error, it will be of type [*PathError].
 func Stat(name string) (FileInfo, error) {
-	fi, err := os.Stat(name)
+	return statName(name, true)
+}
+
+// statOS returns a [FileInfo] built from the results of os.Stat(), or
+// os.Lstat(), if followSymlinks is false.
+func statOS(name string, followSymlinks bool) (FileInfo, error) {
+	var fi os.FileInfo
+
+	var err error
+
+	if followSymlinks {
+		fi, err = os.Stat(name)
+	} else {
+		fi, err = os.Lstat(name)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	return stat(fi, name, true)
+	return stat(fi, name, followSymlinks)
 }
 
 // SupportsATime returns true if FileInfo's ATime() function is supported by the OS.
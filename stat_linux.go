@@ -16,7 +16,13 @@ func (fs *fileStat) times() {
 	fs.ctime = time.Unix(int64(fs.sys.Ctim.Sec), int64(fs.sys.Ctim.Nsec)) //nolint:unconvert // needed conversion
 }
 
+// BTime returns the birth time. On Linux, Stat and Lstat normally populate it
+// from the same statx(2) call as the other fields. It is only looked up here,
+// by path, if statx was unavailable when the FileInfo was created.
 func (fs *fileStat) BTime() time.Time {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
 	if !fs.btimed {
 		fs.btimed = true
 
@@ -29,12 +35,14 @@ func (fs *fileStat) BTime() time.Time {
 
 		err := unix.Statx(unix.AT_FDCWD, fs.path, flags, unix.STATX_BTIME, &stx)
 		if err != nil {
-			fs.err = err
+			fs.setError(FieldBTime, "statx", err)
 
 			return fs.btime
 		}
 
 		if stx.Mask&unix.STATX_BTIME == 0 {
+			fs.setUnsupported(FieldBTime, "statx")
+
 			return fs.btime
 		}
 
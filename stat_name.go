@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !linux || android
+
+package compat
+
+func statName(name string, followSymlinks bool) (FileInfo, error) {
+	return statOS(name, followSymlinks)
+}
@@ -7,6 +7,7 @@ package compat
 
 import (
 	"os"
+	"sync"
 	"syscall"
 	"time"
 
@@ -23,6 +24,8 @@ type fileStat struct {
 	sys    syscall.Dir
 	partID uint64
 	fileID uint64
+	attrs  Attribute
+	mntID  uint64
 	links  uint
 	atime  time.Time
 	btime  time.Time
@@ -37,7 +40,9 @@ type fileStat struct {
 	usered  bool
 	grouped bool
 	// followSymlinks bool // unused
-	err error
+	err  error
+	errs []error
+	mux  sync.Mutex // guards the lazily set fields
 }
 
 func stat(fi os.FileInfo, _ string, _ bool) (FileInfo, error) {
@@ -69,6 +74,9 @@ func (fs *fileStat) BTime() time.Time { return fs.btime }
 func (fs *fileStat) CTime() time.Time { return fs.ctime }
 
 func (fs *fileStat) UID() int {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
 	if !fs.usered {
 		fs.usered = true
 		if fs.user == "" {
@@ -82,6 +90,9 @@ func (fs *fileStat) UID() int {
 }
 
 func (fs *fileStat) GID() int {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
 	if !fs.grouped {
 		fs.grouped = true
 		if fs.group == "" {
@@ -9,10 +9,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"syscall"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
+
+	"github.com/rasa/compat/sid"
 )
 
 const (
@@ -103,73 +106,92 @@ func getPrimaryDomainSID() (*windows.SID, error) {
 	return sidCopy, nil
 }
 
-func getRID(sid *windows.SID) (int, error) {
-	if sid == nil {
-		return UnknownID, os.ErrInvalid
+var (
+	sidMapperMu  sync.Mutex
+	sidMapperSet bool
+	sidMapperVal sid.Mapper
+)
+
+// sidMapper returns the mapper for the local machine's account domain, and
+// the current user's domain. Only a successful result is cached, so a
+// transient LSA failure is retried by the next call.
+func sidMapper() (sid.Mapper, error) {
+	sidMapperMu.Lock()
+	defer sidMapperMu.Unlock()
+
+	if sidMapperSet {
+		return sidMapperVal, nil
 	}
 
-	count := uint32(sid.SubAuthorityCount())
-	if count == 0 {
-		return UnknownID, fmt.Errorf("no subauthorities found for %q", sid.String())
+	m, err := newSIDMapper()
+	if err != nil {
+		return m, err
 	}
 
-	return int(sid.SubAuthority(count - 1)), nil
-}
+	sidMapperVal, sidMapperSet = m, true
 
-var wellKnownSIDToGID = map[string]int{
-	"S-1-1-0":      0x30201, //nolint:mnd // 197121 // Everyone
-	"S-1-5-5-":     0xfff,   //nolint:mnd
-	"S-1-5-32-544": 544,     //nolint:mnd // Administrators
-	"S-1-5-32-545": 545,     //nolint:mnd // Users
-	"S-1-5-32-546": 546,     //nolint:mnd // Guests
-	"S-1-5-32-547": 547,     //nolint:mnd // Power Users
-	"S-1-5-32-548": 548,     //nolint:mnd // Account Operators
-	"S-1-5-32-549": 549,     //nolint:mnd // Server Operators
-	"S-1-5-32-550": 550,     //nolint:mnd // Print Operators
-	"S-1-5-32-551": 551,     //nolint:mnd // Backup Operators
-	"S-1-5-32-552": 552,     //nolint:mnd // Replicators
+	return m, nil
 }
 
-// See https://cygwin.com/cygwin-ug-net/ntsec.html
-func sidToPOSIXID(sid *windows.SID, primaryDomainSid *windows.SID) (int, error) {
-	if sid == nil {
-		return 0, os.ErrInvalid
+func newSIDMapper() (sid.Mapper, error) {
+	var m sid.Mapper
+
+	primary, err := getPrimaryDomainSID()
+	if err != nil {
+		return m, err
 	}
 
-	sidStr := sid.String()
+	m.Primary, err = toSID(primary)
+	if err != nil {
+		return m, err
+	}
 
-	switch {
-	case strings.HasPrefix(sidStr, "S-1-5-32-"):
-		rid, err := getRID(sid)
-		if err != nil {
-			return UnknownID, err
-		}
-		return 0x20000 + rid, nil //nolint:mnd
-	case strings.HasPrefix(sidStr, "S-1-5-21-"):
-		rid, err := getRID(sid)
-		if err != nil {
-			return UnknownID, err
-		}
-		b, err := equalDomainSid(sid, primaryDomainSid)
-		if err != nil {
-			return UnknownID, err
-		}
-		if b {
-			return 0x40000 + rid, nil //nolint:mnd
-		}
+	tokenUser, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return m, fmt.Errorf("failed to get token user: %w", err)
+	}
 
-		return 0x30000 + rid, nil //nolint:mnd
-	default:
-		// fallthrough
+	user, err := toSID(tokenUser.User.Sid)
+	if err != nil {
+		return m, err
 	}
 
-	for prefix, gid := range wellKnownSIDToGID {
-		if strings.HasPrefix(sidStr, prefix) {
-			return gid, nil
-		}
+	// Only accounts in an S-1-5-21 domain map to the 0x30000 range.
+	if strings.HasPrefix(user.String(), "S-1-5-21-") && !user.InDomain(m.Primary) {
+		m.Secondary = user.Domain()
+	}
+
+	return m, nil
+}
+
+// toSID returns s as a sid.SID.
+func toSID(s *windows.SID) (sid.SID, error) {
+	if s == nil {
+		return sid.SID{}, os.ErrInvalid
+	}
+
+	return sid.Parse(s.String())
+}
+
+// sidToPOSIXID returns the POSIX ID of s.
+// See https://cygwin.com/cygwin-ug-net/ntsec.html
+func sidToPOSIXID(s *windows.SID, m sid.Mapper) (int, error) {
+	parsed, err := toSID(s)
+	if err != nil {
+		return UnknownID, err
+	}
+
+	return m.ToPOSIXID(parsed)
+}
+
+// posixIDToSID returns the SID that sidToPOSIXID maps to id.
+func posixIDToSID(id int, m sid.Mapper) (*windows.SID, error) {
+	s, err := m.FromPOSIXID(id)
+	if err != nil {
+		return nil, err
 	}
 
-	return UnknownID, fmt.Errorf("unsupported SID: %s", sidStr)
+	return windows.StringToSid(s.String())
 }
 
 func nameFromSID(sid *windows.SID) (string, error) {
@@ -215,17 +237,17 @@ func getUserGroup(path string) (int, int, string, string, error) {
 		return UnknownID, UnknownID, "", "", err
 	}
 
-	primaryDomainSID, err := getPrimaryDomainSID()
+	m, err := sidMapper()
 	if err != nil {
 		return UnknownID, UnknownID, "", "", err
 	}
 
-	uid, err := sidToPOSIXID(ownerSID, primaryDomainSID)
+	uid, err := sidToPOSIXID(ownerSID, m)
 	if err != nil {
 		return UnknownID, UnknownID, "", "", err
 	}
 
-	gid, err := sidToPOSIXID(groupSID, primaryDomainSID)
+	gid, err := sidToPOSIXID(groupSID, m)
 	if err != nil {
 		return UnknownID, UnknownID, "", "", err
 	}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sys/windows"
 
 	"github.com/rasa/compat"
+	"github.com/rasa/compat/sid"
 )
 
 var invalidSIDs = [][]byte{
@@ -118,50 +119,6 @@ func TestStatPosixWindowsGetPrimaryDomainSID(t *testing.T) {
 	}
 }
 
-func TestStatPosixWindowsGetRid(t *testing.T) {
-	// Build a SID manually: S-1-5-32-544 (Administrators)
-	raw := []byte{
-		1,                // Revision
-		2,                // SubAuthorityCount (2 subauthorities)
-		0, 0, 0, 0, 0, 5, // IdentifierAuthority = 5 (SECURITY_NT_AUTHORITY)
-		32, 0, 0, 0, // SubAuthority[0] = 32 (BUILTIN domain)
-		0x20, 0x02, 0, 0, // SubAuthority[1] = 544 (Administrators)
-	}
-
-	sid := (*windows.SID)(unsafe.Pointer(&raw[0]))
-
-	rid, err := compat.GetRID(sid)
-	if err != nil {
-		t.Fatalf("got %q, want nil", err)
-	}
-	if rid != 544 {
-		t.Fatalf("got %d, want RID 544", rid)
-	}
-}
-
-func TestStatPosixWindowsGetRidInvalid(t *testing.T) {
-	raw := []byte{
-		1,                // Revision
-		0,                // SubAuthorityCount (this is what we want to test)
-		0, 0, 0, 0, 0, 0, // IdentifierAuthority (6 bytes)
-		// no SubAuthorities follow because count = 0
-	}
-
-	sid := (*windows.SID)(unsafe.Pointer(&raw[0]))
-
-	_, err := compat.GetRID(sid)
-	if err == nil {
-		t.Fatal("got nil, want an error")
-	}
-}
-
-func TestStatPosixWindowsGetRidInvalidNil(t *testing.T) {
-	_, err := compat.GetRID(nil)
-	if err == nil {
-		t.Fatal("got nil, want an error")
-	}
-}
-
 func TestStatPosixWindowsGetUserGroup(t *testing.T) {
 	name, err := createTempFile(t)
 	if err != nil {
@@ -243,82 +200,36 @@ func TestStatPosixWindowsNameFromSIDInvalidNil(t *testing.T) {
 }
 
 func TestStatPosixWindowsSIDToPOSIXID(t *testing.T) {
+	m := sid.Mapper{
+		Primary: sid.MustParse("S-1-5-21-111-222-333"),
+	}
+
 	tests := []struct {
-		name       string
-		sidStr     string
-		primaryStr string
-		wantIDMin  int
-		wantIDMax  int
-		equalDom   bool
+		name   string
+		sidStr string
+		want   int
 	}{
-		{
-			name:      "logon session (S-1-5-5-)",
-			sidStr:    "S-1-5-5-0-1",
-			wantIDMin: 0xFFF,
-			wantIDMax: 0xFFF,
-		},
-		{
-			name:      "builtin group (S-1-5-32-)",
-			sidStr:    "S-1-5-32-544", // Administrators
-			wantIDMin: 0x20000,
-			wantIDMax: 0x2FFFF,
-		},
-		{
-			name:       "domain user (same domain)",
-			sidStr:     "S-1-5-21-111-222-333-1000",
-			primaryStr: "S-1-5-21-111-222-333",
-			wantIDMin:  0x40000,
-			wantIDMax:  0x4FFFF,
-			equalDom:   true,
-		},
-		{
-			name:       "domain user (other domain)",
-			sidStr:     "S-1-5-21-111-222-333-1001",
-			primaryStr: "S-1-5-21-999-888-777",
-			wantIDMin:  0x30000,
-			wantIDMax:  0x3FFFF,
-			equalDom:   false,
-		},
-		{
-			name:      "Everyone",
-			sidStr:    "S-1-1-0",
-			wantIDMin: 0x30201,
-			wantIDMax: 0x30201,
-		},
+		{"logon session (S-1-5-5-)", "S-1-5-5-0-1", 0xfff},
+		{"builtin group (S-1-5-32-)", "S-1-5-32-544", 0x20220},
+		{"domain user (same domain)", "S-1-5-21-111-222-333-1000", 0x403e8},
+		{"domain user (other domain)", "S-1-5-21-999-888-777-1001", 0x303e9},
+		{"Everyone", "S-1-1-0", 0x30201},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var sid, primary *windows.SID
-			var err error
-
-			sid, err = windows.StringToSid(tt.sidStr)
+			s, err := windows.StringToSid(tt.sidStr)
 			if err != nil {
 				t.Fatalf("StringToSid(%q) failed: %v", tt.sidStr, err)
 			}
-			if tt.primaryStr != "" {
-				primary, err = windows.StringToSid(tt.primaryStr)
-				if err != nil {
-					t.Fatalf("StringToSid(%q) failed: %v", tt.primaryStr, err)
-				}
-			}
-			got, err := compat.SIDToPOSIXID(sid, primary)
+
+			got, err := compat.SIDToPOSIXID(s, m)
 			if err != nil {
 				t.Fatalf("got %q, want nil", err)
 			}
-			if primary == nil {
-				return
-			}
 
-			if got < tt.wantIDMin {
-				t.Errorf("got 0x%x (%d), want >= 0x%x (%d)", got, got, tt.wantIDMin, tt.wantIDMin)
-			}
-			if got > tt.wantIDMax {
-				t.Errorf("got 0x%x (%d), want <= 0x%x (%d)", got, got, tt.wantIDMax, tt.wantIDMax)
-			}
-			equalDom, _ := compat.EqualDomainSid(sid, primary)
-			if tt.equalDom != equalDom {
-				t.Errorf("got 0x%x (%d), got %v, want %v", got, got, equalDom, tt.equalDom)
+			if got != tt.want {
+				t.Errorf("got 0x%x (%d), want 0x%x (%d)", got, got, tt.want, tt.want)
 			}
 		})
 	}
@@ -326,17 +237,88 @@ func TestStatPosixWindowsSIDToPOSIXID(t *testing.T) {
 
 func TestStatPosixWindowsSIDToPOSIXIDInvalid(t *testing.T) {
 	for i, raw := range invalidSIDs {
-		sid := (*windows.SID)(unsafe.Pointer(&raw[0]))
-		_, err := compat.SIDToPOSIXID(sid, sid)
+		s := (*windows.SID)(unsafe.Pointer(&raw[0]))
+		_, err := compat.SIDToPOSIXID(s, sid.Mapper{})
 		if err == nil {
-			t.Fatalf("test %d: %q: got nil, want an error", i+1, sid.String())
+			t.Fatalf("test %d: %q: got nil, want an error", i+1, s.String())
 		}
 	}
 }
 
 func TestStatPosixWindowsSIDToPOSIXIDInvalidNil(t *testing.T) {
-	_, err := compat.SIDToPOSIXID(nil, nil)
+	_, err := compat.SIDToPOSIXID(nil, sid.Mapper{})
 	if err == nil {
 		t.Fatal("got nil, want an error")
 	}
 }
+
+func TestStatPosixWindowsPosixIDToSIDRoundTrip(t *testing.T) {
+	m, err := compat.SIDMapper()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sids := []string{
+		"S-1-1-0",
+		"S-1-5-32-544",
+		"S-1-5-32-545",
+		m.Primary.String() + "-513",
+		m.Primary.String() + "-1001",
+	}
+
+	for _, str := range sids {
+		want, err := windows.StringToSid(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		id, err := compat.SIDToPOSIXID(want, m)
+		if err != nil {
+			t.Fatalf("SIDToPOSIXID(%v): %v", str, err)
+		}
+
+		got, err := compat.PosixIDToSID(id, m)
+		if err != nil {
+			t.Fatalf("PosixIDToSID(%d): %v", id, err)
+		}
+
+		if !got.Equals(want) {
+			t.Fatalf("PosixIDToSID(%d): got %v, want %v", id, got, str)
+		}
+	}
+}
+
+func TestStatPosixWindowsPosixIDToSIDInvalid(t *testing.T) {
+	_, err := compat.PosixIDToSID(0xfff, sid.Mapper{})
+	if err == nil {
+		t.Fatal("got nil, want an error")
+	}
+}
+
+func TestStatPosixWindowsPosixIDToSIDRanges(t *testing.T) {
+	m, err := compat.SIDMapper()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int{0x20000, 0x20220, 0x2ffff, 0x40000, 0x403e9, 0x4ffff}
+	if !m.Secondary.IsZero() {
+		ids = append(ids, 0x30000, 0x303e9, 0x3ffff)
+	}
+
+	for _, id := range ids {
+		s, err := compat.PosixIDToSID(id, m)
+		if err != nil {
+			t.Fatalf("PosixIDToSID(0x%x): %v", id, err)
+		}
+
+		got, err := compat.SIDToPOSIXID(s, m)
+		if err != nil {
+			t.Fatalf("SIDToPOSIXID(%v): %v", s, err)
+		}
+
+		if got != id {
+			t.Fatalf("SIDToPOSIXID(%v): got 0x%x, want 0x%x", s, got, id)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+const raceGoroutines = 8
+
+// callAccessors calls every FileInfo accessor from several goroutines at
+// once. Run with -race to detect unsynchronized lazy fields.
+func callAccessors(t *testing.T, fi compat.FileInfo) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+
+	results := make([]string, raceGoroutines)
+
+	for i := range raceGoroutines {
+		wg.Go(func() {
+			_ = fi.Name()
+			_ = fi.Size()
+			_ = fi.Mode()
+			_ = fi.ModTime()
+			_ = fi.IsDir()
+			_ = fi.Sys()
+			_ = fi.ATime()
+			_ = fi.BTime()
+			_ = fi.CTime()
+			_ = fi.MTime()
+			_ = fi.Links()
+			_ = fi.UID()
+			_ = fi.GID()
+			_ = fi.User()
+			_ = fi.Group()
+			_ = fi.PartitionID()
+			_ = fi.FileID()
+			_ = fi.Attributes()
+			_ = fi.MountID()
+			_ = fi.Error()
+			_ = fi.Errors()
+			_ = fi.Capabilities()
+			_, _ = fi.Info()
+			results[i] = fi.String()
+		})
+	}
+
+	wg.Wait()
+
+	for i := 1; i < raceGoroutines; i++ {
+		if results[i] != results[0] {
+			t.Errorf("String(): got %q, want %q", results[i], results[0])
+		}
+	}
+}
+
+func TestStatRaceStat(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	callAccessors(t, fi)
+}
+
+func TestStatRaceLstat(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Lstat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	callAccessors(t, fi)
+}
+
+func TestStatRaceFstat(t *testing.T) {
+	if !compat.SupportsFstat() {
+		skip(t, "Skipping test: Fstat() not supported")
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+	defer f.Close()
+
+	fi, err := compat.Fstat(f)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	callAccessors(t, fi)
+}
+
+func TestStatRaceDirEntry(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	entries, err := compat.ReadDir(filepath.Dir(name))
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	for _, entry := range entries {
+		fi, err := entry.Info()
+		if err != nil {
+			fatal(t, err)
+
+			return
+		}
+
+		callAccessors(t, fi)
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const statxMask = unix.STATX_BASIC_STATS | unix.STATX_BTIME | unix.STATX_MNT_ID
+
+// statxUnavailable is set once statx(2) has failed with ENOSYS (kernels
+// before 4.11), or EPERM (seccomp filters that predate statx), so we don't
+// retry it on every call.
+var statxUnavailable atomic.Bool
+
+// statName returns a FileInfo populated by a single statx(2) call, so every
+// field, including the birth time, describes the same file. If statx is not
+// available, it falls back to os.Stat()/os.Lstat().
+func statName(name string, followSymlinks bool) (FileInfo, error) {
+	if statxUnavailable.Load() {
+		return statOS(name, followSymlinks)
+	}
+
+	op := "stat"
+	flags := unix.AT_STATX_SYNC_AS_STAT
+
+	if !followSymlinks {
+		op = "lstat"
+		flags |= unix.AT_SYMLINK_NOFOLLOW
+	}
+
+	var stx unix.Statx_t
+
+	err := ignoringEINTR(func() error {
+		return unix.Statx(unix.AT_FDCWD, name, flags, statxMask, &stx)
+	})
+	if err != nil {
+		if isStatxUnavailable(err) {
+			statxUnavailable.Store(true)
+
+			return statOS(name, followSymlinks)
+		}
+
+		return nil, &os.PathError{Op: op, Path: name, Err: err}
+	}
+
+	return statFromStatx(&stx, filepath.Base(name), name, followSymlinks), nil
+}
+
+// statFromStatx returns a FileInfo populated from stx.
+// See https://github.com/golang/go/blob/8cd6d68a/src/os/stat_linux.go#L12
+func statFromStatx(stx *unix.Statx_t, name, path string, followSymlinks bool) *fileStat {
+	var fs fileStat
+
+	fs.path = path
+	fs.followSymlinks = followSymlinks
+	fs.name = name
+	fs.size = int64(stx.Size) //nolint:gosec // intentional uint64 → int64 conversion
+	fs.mode = statxMode(uint32(stx.Mode))
+	fs.atime = time.Unix(stx.Atime.Sec, int64(stx.Atime.Nsec))
+	fs.ctime = time.Unix(stx.Ctime.Sec, int64(stx.Ctime.Nsec))
+	fs.mtime = time.Unix(stx.Mtime.Sec, int64(stx.Mtime.Nsec))
+
+	// btime is only returned if the filesystem supports it. If it doesn't,
+	// record why BTime() returns a zero time, without failing Error().
+	fs.btimed = true
+	if stx.Mask&unix.STATX_BTIME != 0 {
+		fs.btime = time.Unix(stx.Btime.Sec, int64(stx.Btime.Nsec))
+	} else {
+		fs.setUnsupported(FieldBTime, "statx")
+	}
+
+	// Populate Sys(), so it returns the same *syscall.Stat_t as os.Stat().
+	setInt(&fs.sys.Dev, unix.Mkdev(stx.Dev_major, stx.Dev_minor))
+	setInt(&fs.sys.Ino, stx.Ino)
+	setInt(&fs.sys.Nlink, uint64(stx.Nlink))
+	setInt(&fs.sys.Mode, uint64(stx.Mode))
+	setInt(&fs.sys.Uid, uint64(stx.Uid))
+	setInt(&fs.sys.Gid, uint64(stx.Gid))
+	setInt(&fs.sys.Rdev, unix.Mkdev(stx.Rdev_major, stx.Rdev_minor))
+	setInt(&fs.sys.Size, stx.Size)
+	setInt(&fs.sys.Blksize, uint64(stx.Blksize))
+	setInt(&fs.sys.Blocks, stx.Blocks)
+	fs.sys.Atim = syscall.NsecToTimespec(fs.atime.UnixNano())
+	fs.sys.Mtim = syscall.NsecToTimespec(fs.mtime.UnixNano())
+	fs.sys.Ctim = syscall.NsecToTimespec(fs.ctime.UnixNano())
+
+	fs.partID = uint64(fs.sys.Dev) //nolint:gosec,unconvert,nolintlint // intentional int32 → uint64 conversion
+	fs.fileID = stx.Ino
+	fs.links = uint(stx.Nlink)
+	fs.uid = int(stx.Uid)
+	fs.gid = int(stx.Gid)
+	fs.attrs = statxAttributes(stx.Attributes & stx.Attributes_mask)
+
+	if stx.Mask&unix.STATX_MNT_ID != 0 {
+		fs.mntID = stx.Mnt_id
+	}
+
+	return &fs
+}
+
+var statxAttributeMap = []struct {
+	statx uint64
+	attr  Attribute
+}{
+	{unix.STATX_ATTR_IMMUTABLE, AttributeImmutable},
+	{unix.STATX_ATTR_APPEND, AttributeAppend},
+	{unix.STATX_ATTR_COMPRESSED, AttributeCompressed},
+	{unix.STATX_ATTR_ENCRYPTED, AttributeEncrypted},
+	{unix.STATX_ATTR_NODUMP, AttributeNoDump},
+	{unix.STATX_ATTR_DAX, AttributeDAX},
+	{unix.STATX_ATTR_VERITY, AttributeVerity},
+}
+
+// statxAttributes converts stx_attributes bits to an Attribute set.
+func statxAttributes(attrs uint64) Attribute {
+	var a Attribute
+
+	for _, m := range statxAttributeMap {
+		if attrs&m.statx != 0 {
+			a |= m.attr
+		}
+	}
+
+	return a
+}
+
+// statxMode converts a st_mode value to an os.FileMode.
+// See https://github.com/golang/go/blob/8cd6d68a/src/os/stat_linux.go#L19-L44
+func statxMode(mode uint32) os.FileMode {
+	fm := os.FileMode(mode & 0o777) //nolint:mnd
+
+	switch mode & syscall.S_IFMT {
+	case syscall.S_IFBLK:
+		fm |= os.ModeDevice
+	case syscall.S_IFCHR:
+		fm |= os.ModeDevice | os.ModeCharDevice
+	case syscall.S_IFDIR:
+		fm |= os.ModeDir
+	case syscall.S_IFIFO:
+		fm |= os.ModeNamedPipe
+	case syscall.S_IFLNK:
+		fm |= os.ModeSymlink
+	case syscall.S_IFREG:
+		// nothing to do
+	case syscall.S_IFSOCK:
+		fm |= os.ModeSocket
+	}
+
+	if mode&syscall.S_ISGID != 0 {
+		fm |= os.ModeSetgid
+	}
+
+	if mode&syscall.S_ISUID != 0 {
+		fm |= os.ModeSetuid
+	}
+
+	if mode&syscall.S_ISVTX != 0 {
+		fm |= os.ModeSticky
+	}
+
+	return fm
+}
+
+func isStatxUnavailable(err error) bool {
+	return errors.Is(err, unix.ENOSYS) || errors.Is(err, unix.EPERM)
+}
+
+// setInt sets *dst to v. The syscall.Stat_t field types vary by
+// architecture, so a plain conversion won't compile everywhere.
+func setInt[T ~int32 | ~int64 | ~uint32 | ~uint64](dst *T, v uint64) {
+	*dst = T(v) //nolint:gosec // intentional conversion
+}
+
+// See https://github.com/golang/go/blob/8cd6d68a/src/os/file_posix.go#L243
+func ignoringEINTR(fn func() error) error {
+	for {
+		err := fn()
+		if !errors.Is(err, unix.EINTR) {
+			return err
+		}
+	}
+}
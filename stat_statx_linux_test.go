@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/rasa/compat"
+)
+
+func TestStatxStatMatchesOSStat(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	osfi, err := os.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	compareStatT(t, fi, osfi)
+}
+
+func TestStatxLstatMatchesOSLstat(t *testing.T) {
+	if !supportsSymlinks(t) {
+		return
+	}
+
+	_, link, err := createTempSymlink(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi, err := compat.Lstat(link)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	osfi, err := os.Lstat(link)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	compareStatT(t, fi, osfi)
+}
+
+func TestStatxStatDir(t *testing.T) {
+	dir := tempDir(t)
+
+	fi, err := compat.Stat(dir)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	osfi, err := os.Stat(dir)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	compareStatT(t, fi, osfi)
+}
+
+func TestStatxStatNotExist(t *testing.T) {
+	name := filepath.Join(tempDir(t), "does-not-exist")
+
+	_, err := compat.Stat(name)
+	if !os.IsNotExist(err) {
+		fatalf(t, "Stat: got %v, want ErrNotExist", err)
+	}
+
+	var pe *os.PathError
+
+	_, err = compat.Lstat(name)
+	if !os.IsNotExist(err) {
+		fatalf(t, "Lstat: got %v, want ErrNotExist", err)
+
+		return
+	}
+
+	if !errors.As(err, &pe) || pe.Op != "lstat" {
+		fatalf(t, "Lstat: got %#v, want a *PathError with Op lstat", err)
+	}
+}
+
+func TestStatxFallback(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	old := compat.StatxUnavailable.Load()
+	compat.StatxUnavailable.Store(true)
+
+	t.Cleanup(func() { compat.StatxUnavailable.Store(old) })
+
+	fi, err := compat.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	osfi, err := os.Stat(name)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	compareStatT(t, fi, osfi)
+
+	// the lazy statx path is still used for BTime()
+	_ = fi.BTime()
+}
+
+func TestStatxMode(t *testing.T) {
+	tests := []struct {
+		mode uint32
+		want os.FileMode
+	}{
+		{syscall.S_IFREG | 0o644, 0o644},
+		{syscall.S_IFDIR | 0o755, os.ModeDir | 0o755},
+		{syscall.S_IFLNK | 0o777, os.ModeSymlink | 0o777},
+		{syscall.S_IFIFO | 0o600, os.ModeNamedPipe | 0o600},
+		{syscall.S_IFSOCK | 0o600, os.ModeSocket | 0o600},
+		{syscall.S_IFBLK | 0o600, os.ModeDevice | 0o600},
+		{syscall.S_IFCHR | 0o600, os.ModeDevice | os.ModeCharDevice | 0o600},
+		{syscall.S_IFREG | syscall.S_ISUID | syscall.S_ISGID | 0o755, os.ModeSetuid | os.ModeSetgid | 0o755},
+		{syscall.S_IFDIR | syscall.S_ISVTX | 0o777, os.ModeDir | os.ModeSticky | 0o777},
+	}
+
+	for _, tt := range tests {
+		if got := compat.StatxMode(tt.mode); got != tt.want {
+			t.Errorf("statxMode(0o%o): got %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func compareStatT(t *testing.T, fi compat.FileInfo, osfi os.FileInfo) {
+	t.Helper()
+
+	got, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		fatalf(t, "Sys(): got %T, want *syscall.Stat_t", fi.Sys())
+
+		return
+	}
+
+	want, _ := osfi.Sys().(*syscall.Stat_t)
+
+	if got.Dev != want.Dev || got.Ino != want.Ino || got.Mode != want.Mode ||
+		got.Nlink != want.Nlink || got.Uid != want.Uid || got.Gid != want.Gid ||
+		got.Rdev != want.Rdev || got.Size != want.Size || got.Blocks != want.Blocks ||
+		got.Mtim != want.Mtim {
+		fatalf(t, "Sys(): got %+v, want %+v", *got, *want)
+
+		return
+	}
+
+	if fi.Name() != osfi.Name() {
+		t.Errorf("Name(): got %v, want %v", fi.Name(), osfi.Name())
+	}
+
+	if fi.Mode() != osfi.Mode() {
+		t.Errorf("Mode(): got %v, want %v", fi.Mode(), osfi.Mode())
+	}
+
+	if !fi.ModTime().Equal(osfi.ModTime()) {
+		t.Errorf("ModTime(): got %v, want %v", fi.ModTime(), osfi.ModTime())
+	}
+
+	if fi.PartitionID() != uint64(want.Dev) || fi.FileID() != want.Ino { //nolint:unconvert,nolintlint
+		t.Errorf("PartitionID()/FileID(): got %v/%v, want %v/%v", fi.PartitionID(), fi.FileID(), want.Dev, want.Ino)
+	}
+}
+
+func TestStatxAttributes(t *testing.T) {
+	tests := []struct {
+		statx uint64
+		want  compat.Attribute
+	}{
+		{0, 0},
+		{unix.STATX_ATTR_IMMUTABLE, compat.AttributeImmutable},
+		{unix.STATX_ATTR_APPEND, compat.AttributeAppend},
+		{unix.STATX_ATTR_COMPRESSED, compat.AttributeCompressed},
+		{unix.STATX_ATTR_ENCRYPTED, compat.AttributeEncrypted},
+		{unix.STATX_ATTR_NODUMP, compat.AttributeNoDump},
+		{unix.STATX_ATTR_DAX, compat.AttributeDAX},
+		{unix.STATX_ATTR_VERITY, compat.AttributeVerity},
+		{unix.STATX_ATTR_MOUNT_ROOT | unix.STATX_ATTR_NODUMP, compat.AttributeNoDump},
+	}
+
+	for _, tt := range tests {
+		if got := compat.StatxAttributes(tt.statx); got != tt.want {
+			t.Errorf("statxAttributes(0x%x): got %v, want %v", tt.statx, got, tt.want)
+		}
+	}
+}
+
+func TestStatxMountID(t *testing.T) {
+	name1, err := createTempFile(t)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	fi1, err := compat.Stat(name1)
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	if fi1.MountID() == 0 {
+		skip(t, "Skipping test: MountID() not supported by the kernel")
+
+		return
+	}
+
+	fi2, err := compat.Stat(filepath.Dir(name1))
+	if err != nil {
+		fatal(t, err)
+
+		return
+	}
+
+	if got, want := fi1.MountID(), fi2.MountID(); got != want {
+		t.Errorf("MountID(): got %v, want %v", got, want)
+	}
+}
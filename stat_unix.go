@@ -7,6 +7,7 @@ package compat
 
 import (
 	"os"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -21,6 +22,9 @@ type fileStat struct {
 	sys    syscall.Stat_t
 	partID uint64
 	fileID uint64
+	attrs  Attribute
+	mntID  uint64
+	caps   *FileCaps
 	links  uint
 	atime  time.Time
 	btime  time.Time
@@ -34,6 +38,9 @@ type fileStat struct {
 	// ctimed bool // unused
 	usered         bool
 	grouped        bool
+	capsed         bool
 	followSymlinks bool
 	err            error
+	errs           []error
+	mux            sync.Mutex // guards the lazily set fields
 }
@@ -10,8 +10,6 @@ package compat
 
 import (
 	"os"
-	"os/user"
-	"strconv"
 	"syscall"
 	"time"
 )
@@ -47,14 +45,17 @@ func (fs *fileStat) UID() int { return fs.uid }
 func (fs *fileStat) GID() int { return fs.gid }
 
 func (fs *fileStat) User() string {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
 	if !fs.usered {
 		fs.usered = true
 
-		u, err := user.LookupId(strconv.Itoa(fs.uid))
+		name, err := resolver(buildOptions()).UserName(fs.uid)
 		if err != nil {
-			fs.err = err
+			fs.setError(FieldUser, "lookup", err)
 		} else {
-			fs.user = u.Username
+			fs.user = name
 		}
 	}
 
@@ -62,14 +63,17 @@ func (fs *fileStat) User() string {
 }
 
 func (fs *fileStat) Group() string {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
 	if !fs.grouped {
 		fs.grouped = true
 
-		g, err := user.LookupGroupId(strconv.Itoa(fs.gid))
+		name, err := resolver(buildOptions()).GroupName(fs.gid)
 		if err != nil {
-			fs.err = err
+			fs.setError(FieldGroup, "lookup", err)
 		} else {
-			fs.group = g.Name
+			fs.group = name
 		}
 	}
 
@@ -31,6 +31,8 @@ type fileStat struct {
 	sys    syscall.Win32FileAttributeData
 	partID uint64
 	fileID uint64
+	attrs  Attribute
+	mntID  uint64
 	links  uint
 	atime  time.Time
 	btime  time.Time
@@ -46,7 +48,8 @@ type fileStat struct {
 	// grouped bool unused
 	followSymlinks bool
 	err            error
-	mux            sync.Mutex // Windows only
+	errs           []error
+	mux            sync.Mutex // guards the lazily set fields
 	path16         []uint16   // Windows only
 	origName       string     // Windows only
 }
@@ -120,6 +123,14 @@ func stat(fi os.FileInfo, name string, followSymlinks bool) (FileInfo, error) {
 	fs.btime = time.Unix(0, fs.sys.CreationTime.Nanoseconds())
 	fs.followSymlinks = followSymlinks
 
+	if fs.sys.FileAttributes&windows.FILE_ATTRIBUTE_COMPRESSED != 0 {
+		fs.attrs |= AttributeCompressed
+	}
+
+	if fs.sys.FileAttributes&windows.FILE_ATTRIBUTE_ENCRYPTED != 0 {
+		fs.attrs |= AttributeEncrypted
+	}
+
 	return &fs, nil
 }
 
@@ -128,6 +139,9 @@ func (fs *fileStat) BTime() time.Time {
 }
 
 func (fs *fileStat) CTime() time.Time {
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
 	if !fs.ctimed {
 		fs.ctimed = true
 
@@ -137,12 +151,9 @@ func (fs *fileStat) CTime() time.Time {
 		}
 
 		// See https://github.com/golang/go/blob/3cf1aaf8/src/os/types_windows.go#L288
-		fs.mux.Lock()
-		defer fs.mux.Unlock()
-
 		h, err := windows.CreateFile(&fs.path16[0], 0, 0, nil, windows.OPEN_EXISTING, attrs, 0)
 		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
+			fs.setError(FieldCTime, "CreateFile", &os.PathError{Op: "stat", Path: fs.origName, Err: err})
 
 			return fs.ctime
 		}
@@ -152,7 +163,7 @@ func (fs *fileStat) CTime() time.Time {
 
 		err = windows.GetFileInformationByHandleEx(h, windows.FileBasicInfo, (*byte)(unsafe.Pointer(&bi)), uint32(unsafe.Sizeof(bi)))
 		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
+			fs.setError(FieldCTime, "GetFileInformationByHandleEx", &os.PathError{Op: "stat", Path: fs.origName, Err: err})
 
 			return fs.ctime
 		}
@@ -175,57 +186,61 @@ func (fs *fileStat) CTime() time.Time {
 }
 
 func (fs *fileStat) UID() int {
-	if !fs.usered {
-		fs.usered = true
-		var err error
-		fs.uid, fs.gid, fs.user, fs.group, err = getUserGroup(fs.path)
-		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
-		}
-	}
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	fs.userGroup(FieldUID)
 
 	return fs.uid
 }
 
 func (fs *fileStat) GID() int {
-	if !fs.usered {
-		fs.usered = true
-		var err error
-		fs.uid, fs.gid, fs.user, fs.group, err = getUserGroup(fs.path)
-		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
-		}
-	}
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	fs.userGroup(FieldGID)
 
 	return fs.gid
 }
 
 func (fs *fileStat) User() string {
-	if !fs.usered {
-		fs.usered = true
-		var err error
-		fs.uid, fs.gid, fs.user, fs.group, err = getUserGroup(fs.path)
-		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
-		}
-	}
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	fs.userGroup(FieldUser)
 
 	return fs.user
 }
 
 func (fs *fileStat) Group() string {
-	if !fs.usered {
-		fs.usered = true
-		var err error
-		fs.uid, fs.gid, fs.user, fs.group, err = getUserGroup(fs.path)
-		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
-		}
-	}
+	fs.mux.Lock()
+	defer fs.mux.Unlock()
+
+	fs.userGroup(FieldGroup)
 
 	return fs.group
 }
 
+// userGroup looks up the owner and group, once. As a single lookup populates
+// all four fields, any error is recorded once, against field, the field whose
+// accessor triggered the lookup.
+// The caller must hold fs.mux.
+func (fs *fileStat) userGroup(field string) {
+	if fs.usered {
+		return
+	}
+
+	fs.usered = true
+
+	var err error
+
+	fs.uid, fs.gid, fs.user, fs.group, err = getUserGroup(fs.path)
+	if err != nil {
+		err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
+		fs.setError(field, "getUserGroup", err)
+	}
+}
+
 func (fs *fileStat) stat() (os.FileMode, error) {
 	b, err := supportsACLsCached(fs)
 	if err == nil && !b {
@@ -238,14 +253,16 @@ func (fs *fileStat) stat() (os.FileMode, error) {
 
 	perm, err := acl.GetExplicitFileAccessMode(fs.path)
 	if err != nil {
-		fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
-		return perm, fs.err
+		err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
+		fs.setError(FieldMode, "GetExplicitFileAccessMode", err)
+		return perm, err
 	}
 	if perm == perm000 {
 		b, err = supportsACLs(fs.path)
 		if err != nil {
-			fs.err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
-			return perm, fs.err
+			err = &os.PathError{Op: "stat", Path: fs.origName, Err: err}
+			fs.setError(FieldMode, "supportsACLs", err)
+			return perm, err
 		}
 		if !b {
 			if fs.mode.IsDir() {
@@ -1,7 +1,7 @@
 // SPDX-FileCopyrightText: Copyright © 2025 Ross Smith II <ross@smithii.com>
 // SPDX-License-Identifier: MIT
 
-//go:build (darwin && !ios) || ((freebsd || netbsd) && fstat) || (linux && !android) || windows
+//go:build (darwin && !ios) || ((freebsd || netbsd) && fstat) || windows
 
 // The darwin build flag includes ios (which doesn't support Nice())
 
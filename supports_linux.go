@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package compat
+
+const (
+	supportsATime         = true
+	supportsATimeSetting  = true
+	supportsAtomicReplace = true
+	supportsBTime         = true
+	supportsCTime         = true
+	supportsFstat         = true
+	supportsLinks         = true
+	supportsNice          = true
+	supportsRelativeFstat = true // Fstat() queries the file descriptor itself
+	supportsSymlinks      = true
+	supportsUmask         = true
+)
+
+const userIDSource UserIDSourceType = UserIDSourceIsInt
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package compat
+
+import (
+	"os"
+)
+
+// syncDirOS opens the directory read-only, and calls File.Sync, which is
+// fsync(2) on Unix, fcntl(F_FULLFSYNC) on macOS and iOS, and a null
+// wstat(5) on Plan 9.
+func syncDirOS(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return f.Sync()
+}
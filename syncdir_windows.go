@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package compat
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/rasa/compat/golang"
+)
+
+// syncDirOS opens the directory for writing, which FlushFileBuffers
+// requires, and flushes it. NTFS journals directory changes, so if the
+// directory can't be opened for writing, or the filesystem can't flush it,
+// the error is reported as unsupported.
+func syncDirOS(dir string) error {
+	dir16, err := windows.UTF16PtrFromString(golang.FixLongPath(dir))
+	if err != nil {
+		return err
+	}
+
+	h, err := windows.CreateFile(
+		dir16,
+		windows.GENERIC_WRITE,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil,
+		windows.OPEN_EXISTING,
+		windows.FILE_FLAG_BACKUP_SEMANTICS,
+		0,
+	)
+	if err != nil {
+		return syncDirWindowsError(err)
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	return syncDirWindowsError(windows.FlushFileBuffers(h))
+}
+
+func syncDirWindowsError(err error) error {
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) || errors.Is(err, windows.ERROR_INVALID_FUNCTION) {
+		return &UnsupportedError{Op: "flushfilebuffers"}
+	}
+
+	return err
+}
@@ -7,10 +7,11 @@
 package compat
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 )
 
 // WriteReader writes r to the named file, creating it if necessary.
@@ -34,21 +35,75 @@ import (
 // Additional option arguments can be used to change the default configuration
 // for the target file.
 //
+// Use the WithContext option to cancel the write, and the WithProgress option
+// to report the number of bytes written. If the write is cancelled in atomic
+// mode, the temporary file is removed, and the destination is unchanged.
+//
 // On Plan 9, atomic creation of a new file is supported, but atomic replacement
 // of an existing file is not. If the destination exists, WriteReader returns an
 // error matching errors.ErrUnsupported and leaves the destination unchanged.
 // To work around this issue, use the WithNonAtomicReplace option.
-func WriteReader(name string, reader io.Reader, perm os.FileMode, opts ...Option) (err error) { //nolint:funlen,gocyclo
+func WriteReader(name string, reader io.Reader, perm os.FileMode, opts ...Option) error {
+	fopts, fileMode, err := writeOptions(name, perm, opts)
+	if err != nil {
+		return err
+	}
+
+	if fopts.ctx != nil || fopts.progress != nil {
+		reader = newProgressReader(reader, &fopts)
+	}
+
+	if !fopts.atomically {
+		err = writeReader(name, reader, fopts.flags, fileMode)
+		if err != nil {
+			return err
+		}
+
+		return syncParents(fopts.durable, name)
+	}
+
+	// write to a temp file first, then we'll atomically replace the target file
+	// with the temp file.
+	w, err := newAtomicWriter(name, fileMode, fopts)
+	if err != nil {
+		return err
+	}
+	// Don't leave the temp file lying around on error. Close does nothing
+	// once the file has been committed.
+	defer w.Close()
+
+	_, err = io.Copy(w.file, reader)
+	if err != nil {
+		err = fmt.Errorf("cannot write to '%v': %w", w.file.Name(), err)
+
+		return writeError(name, err)
+	}
+
+	return w.Commit()
+}
+
+// writeOptions returns the options, and the file mode, used to write the
+// named file by WriteReader and NewAtomicWriter.
+func writeOptions(name string, perm os.FileMode, opts []Option) (Options, os.FileMode, error) {
 	fopts := Options{
 		flags:        os.O_CREATE | os.O_WRONLY | os.O_TRUNC,
 		fileMode:     perm,
 		keepFileMode: true,
+		// a global WithDurability, and WithTempPattern, apply to writes, too.
+		durable:     optionsPtr.Load().durable,
+		tempPattern: optionsPtr.Load().tempPattern,
 	}
 
 	for _, opt := range opts {
 		opt(&fopts)
 	}
 
+	// don't truncate the file if the write was cancelled before it started.
+	err := contextErr(fopts.ctx)
+	if err != nil {
+		return fopts, 0, writeError(name, err)
+	}
+
 	var fileMode os.FileMode
 	// change default file mode for when file does not exist yet.
 	if fopts.defaultFileMode != 0 {
@@ -58,11 +113,9 @@ func WriteReader(name string, reader io.Reader, perm os.FileMode, opts ...Option
 	// get the file mode from the original file and use that for the replacement
 	// file, too.
 	if fopts.keepFileMode {
-		var destInfo os.FileInfo
-
-		destInfo, err = Stat(name)
+		destInfo, err := Stat(name)
 		if err != nil && !os.IsNotExist(err) {
-			return err
+			return fopts, 0, err
 		}
 
 		if destInfo != nil {
@@ -84,84 +137,117 @@ func WriteReader(name string, reader io.Reader, perm os.FileMode, opts ...Option
 		}
 	}
 
-	if !fopts.atomically {
-		return writeReader(name, reader, fopts.flags, fileMode)
-	}
-
-	// write to a temp file first, then we'll atomically replace the target file
-	// with the temp file.
-	dir, _ := filepath.Split(name)
-	if dir == "" {
-		dir = "."
-	}
+	return fopts, fileMode, nil
+}
 
-	file, err := createTemp(dir, "~*.tmp", fileMode, fopts.flags)
+func writeReader(name string, reader io.Reader, flag int, perm os.FileMode) error {
+	file, err := openFile(name, flag, perm)
 	if err != nil {
-		err = fmt.Errorf("cannot create tempfile: %w", err)
-
 		return writeError(name, err)
 	}
-
-	tempFileName := file.Name()
-
-	defer func() {
-		if err != nil {
-			// Don't leave the temp file lying around on error.
-			_ = Chmod(tempFileName, CreateTempPerm) // 0o600
-			_ = Remove(tempFileName)
-		}
-	}()
-	// ensure we always close f. Note that this does not conflict with the
-	// close below, as close is idempotent.
 	defer file.Close()
 
 	_, err = io.Copy(file, reader)
 	if err != nil {
-		err = fmt.Errorf("cannot write to '%v': %w", tempFileName, err)
-
 		return writeError(name, err)
 	}
-	// fsync is important, otherwise os.Rename could rename a zero-length file
+
 	err = file.Sync()
 	if err != nil {
-		err = fmt.Errorf("cannot sync '%v': %w", tempFileName, err)
-
 		return writeError(name, err)
 	}
 
-	err = file.Close()
-	if err != nil {
-		err = fmt.Errorf("cannot close '%v': %w", tempFileName, err)
+	return nil
+}
 
-		return writeError(name, err)
+// copyBufferSize is the size of the buffer io.Copy uses.
+const copyBufferSize = 32 * 1024
+
+// progressReader checks the context before each read, and reports the
+// number of bytes written to the progress function. io.Copy calls its WriteTo
+// method, so the count is the number of bytes written, not just read.
+type progressReader struct {
+	reader   io.Reader
+	ctx      context.Context //nolint:containedctx // checked before each read
+	progress func(int64)
+	interval int64
+	reported int64
+	called   bool
+}
+
+func newProgressReader(reader io.Reader, fopts *Options) *progressReader {
+	return &progressReader{
+		reader:   reader,
+		ctx:      fopts.ctx,
+		progress: fopts.progress,
+		interval: fopts.progressInterval,
 	}
+}
 
-	err = Rename(tempFileName, name, WithNonAtomicReplace(fopts.nonAtomicReplace))
+func (r *progressReader) Read(p []byte) (int, error) {
+	err := contextErr(r.ctx)
 	if err != nil {
-		err = fmt.Errorf("cannot rename to '%v': %w", tempFileName, err)
-
-		return writeError(name, err)
+		return 0, err
 	}
 
-	return nil
+	return r.reader.Read(p)
 }
 
-func writeReader(name string, reader io.Reader, flag int, perm os.FileMode) error {
-	file, err := openFile(name, flag, perm)
-	if err != nil {
-		return writeError(name, err)
+// WriteTo writes the reader's contents to w. It's modeled on io.copyBuffer.
+func (r *progressReader) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, copyBufferSize)
+
+	var written int64
+
+	for {
+		nr, err := r.Read(buf)
+		if nr > 0 {
+			nw, ew := w.Write(buf[:nr])
+			written += int64(nw)
+
+			if ew != nil {
+				return written, ew
+			}
+
+			if nw != nr {
+				return written, io.ErrShortWrite
+			}
+
+			if written-r.reported >= r.interval {
+				r.report(written)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			// always report the total, once the write completes.
+			if !r.called || written != r.reported {
+				r.report(written)
+			}
+
+			return written, nil
+		}
+
+		if err != nil {
+			return written, err
+		}
 	}
-	defer file.Close()
+}
 
-	_, err = io.Copy(file, reader)
-	if err != nil {
-		return writeError(name, err)
+func (r *progressReader) report(written int64) {
+	if r.progress == nil {
+		return
 	}
 
-	err = file.Sync()
-	if err != nil {
-		return writeError(name, err)
+	r.called = true
+	r.reported = written
+	r.progress(written)
+}
+
+// contextErr returns ctx's error, if ctx is not nil.
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
 	}
 
-	return nil
+	return ctx.Err()
 }
@@ -7,9 +7,13 @@
 package compat_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/rasa/compat"
@@ -524,3 +528,151 @@ func TestWriteReaderExportedReaderInvalid(t *testing.T) {
 		t.Fatalf("got nil, want an error")
 	}
 }
+
+// cancelReader cancels its context after the first read.
+type cancelReader struct {
+	cancel context.CancelFunc
+}
+
+func (r cancelReader) Read(p []byte) (int, error) {
+	r.cancel()
+
+	return copy(p, helloBytes), nil
+}
+
+func TestWriteReaderWithContextCancelled(t *testing.T) {
+	dir := tempDir(t)
+	file := filepath.Join(dir, "file.txt")
+
+	err := os.WriteFile(file, helloBytes, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	opts := []compat.Option{compat.WithAtomicity(true), compat.WithContext(ctx)}
+	if compat.IsPlan9 {
+		opts = append(opts, compat.WithNonAtomicReplace(true))
+	}
+
+	err = compat.WriteReader(file, cancelReader{cancel: cancel}, perm600, opts...)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("got %q, want %q", got, helloBytes)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1 (the temp file was not removed)", len(entries))
+	}
+}
+
+func TestWriteReaderWithContextCancelledNonAtomic(t *testing.T) {
+	file := tempName(t)
+
+	cleanup(t, file)
+
+	err := os.WriteFile(file, helloBytes, perm600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err = compat.WriteReader(file, nilReader{}, perm600, compat.WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("got %q, want %q (the file was truncated)", got, helloBytes)
+	}
+}
+
+func TestWriteReaderWithProgress(t *testing.T) {
+	const size = 100 * 1024
+
+	tests := []struct {
+		name     string
+		interval int64
+		want     []int64
+	}{
+		{"every write", 0, []int64{32768, 65536, 98304, size}},
+		{"interval", 40000, []int64{65536, size}},
+		{"larger than size", 2 * size, []int64{size}},
+	}
+
+	for _, atomically := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				file := tempName(t)
+
+				cleanup(t, file)
+
+				var got []int64
+
+				opts := []compat.Option{
+					compat.WithAtomicity(atomically),
+					compat.WithProgress(func(written int64) { got = append(got, written) }),
+					compat.WithProgressInterval(tt.interval),
+				}
+
+				err := compat.WriteReader(file, bytes.NewReader(make([]byte, size)), perm600, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !slices.Equal(got, tt.want) {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+
+				fi, err := os.Stat(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if fi.Size() != size {
+					t.Fatalf("got %d bytes, want %d", fi.Size(), size)
+				}
+			})
+		}
+	}
+}
+
+func TestWriteReaderWithProgressEmpty(t *testing.T) {
+	file := tempName(t)
+
+	cleanup(t, file)
+
+	var got []int64
+
+	err := compat.WriteReader(file, bytes.NewReader(nil), perm600,
+		compat.WithProgress(func(written int64) { got = append(got, written) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(got, []int64{0}) {
+		t.Fatalf("got %v, want [0]", got)
+	}
+}
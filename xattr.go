@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat
+
+import (
+	"errors"
+	"os"
+)
+
+// Flags used by the SetXattr functions.
+const (
+	// XattrCreate fails if the extended attribute already exists.
+	XattrCreate = 1 << iota
+	// XattrReplace fails if the extended attribute doesn't exist.
+	XattrReplace
+)
+
+// ErrNoXattr is returned if the extended attribute doesn't exist
+// (ENODATA on Linux, ENOATTR on macOS).
+var ErrNoXattr = errors.New("no such extended attribute")
+
+// ListXattr returns the names of the extended attributes of the named file.
+// If the file is a symbolic link, it lists the attributes of the link's
+// target.
+//
+// Extended attributes are supported on Linux and macOS. On other operating
+// systems, and on filesystems that don't support extended attributes, the
+// error wraps an *UnsupportedError.
+// If there is an error, it will be of type [*PathError].
+func ListXattr(name string) ([]string, error) {
+	return listXattr(name, true)
+}
+
+// LListXattr is like ListXattr, but if the file is a symbolic link, it lists
+// the attributes of the link itself.
+// If there is an error, it will be of type [*PathError].
+func LListXattr(name string) ([]string, error) {
+	return listXattr(name, false)
+}
+
+// FListXattr is like ListXattr, but lists the attributes of the open file f.
+// If there is an error, it will be of type [*PathError].
+func FListXattr(f *os.File) ([]string, error) {
+	return flistXattr(f)
+}
+
+// GetXattr returns the value of the extended attribute attr of the named
+// file. If the attribute doesn't exist, the error wraps [ErrNoXattr].
+// If the file is a symbolic link, it returns the attribute of the link's
+// target.
+// If there is an error, it will be of type [*PathError].
+func GetXattr(name, attr string) ([]byte, error) {
+	return getXattr(name, attr, true)
+}
+
+// LGetXattr is like GetXattr, but if the file is a symbolic link, it returns
+// the attribute of the link itself.
+// If there is an error, it will be of type [*PathError].
+func LGetXattr(name, attr string) ([]byte, error) {
+	return getXattr(name, attr, false)
+}
+
+// FGetXattr is like GetXattr, but returns the attribute of the open file f.
+// If there is an error, it will be of type [*PathError].
+func FGetXattr(f *os.File, attr string) ([]byte, error) {
+	return fgetXattr(f, attr)
+}
+
+// SetXattr sets the extended attribute attr of the named file to data.
+// The flags parameter is 0, XattrCreate, or XattrReplace.
+// If the file is a symbolic link, it sets the attribute of the link's target.
+// If there is an error, it will be of type [*PathError].
+func SetXattr(name, attr string, data []byte, flags int) error {
+	return setXattr(name, attr, data, flags, true)
+}
+
+// LSetXattr is like SetXattr, but if the file is a symbolic link, it sets the
+// attribute of the link itself. Linux only permits trusted.* and security.*
+// attributes on symbolic links.
+// If there is an error, it will be of type [*PathError].
+func LSetXattr(name, attr string, data []byte, flags int) error {
+	return setXattr(name, attr, data, flags, false)
+}
+
+// FSetXattr is like SetXattr, but sets the attribute of the open file f.
+// If there is an error, it will be of type [*PathError].
+func FSetXattr(f *os.File, attr string, data []byte, flags int) error {
+	return fsetXattr(f, attr, data, flags)
+}
+
+// RemoveXattr removes the extended attribute attr of the named file.
+// If the attribute doesn't exist, the error wraps [ErrNoXattr].
+// If the file is a symbolic link, it removes the attribute of the link's
+// target.
+// If there is an error, it will be of type [*PathError].
+func RemoveXattr(name, attr string) error {
+	return removeXattr(name, attr, true)
+}
+
+// LRemoveXattr is like RemoveXattr, but if the file is a symbolic link, it
+// removes the attribute of the link itself.
+// If there is an error, it will be of type [*PathError].
+func LRemoveXattr(name, attr string) error {
+	return removeXattr(name, attr, false)
+}
+
+// FRemoveXattr is like RemoveXattr, but removes the attribute of the open
+// file f.
+// If there is an error, it will be of type [*PathError].
+func FRemoveXattr(f *os.File, attr string) error {
+	return fremoveXattr(f, attr)
+}
+
+// SupportsXattr returns true if extended attributes are supported by the
+// OS. Individual filesystems may still not support them.
+func SupportsXattr() bool {
+	return supportsXattr
+}
+
+// fileName returns f's name, or "" if f is nil.
+func fileName(f *os.File) string {
+	if f == nil {
+		return ""
+	}
+
+	return f.Name()
+}
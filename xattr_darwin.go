@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin
+
+package compat
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+const errNoXattr = unix.ENOATTR
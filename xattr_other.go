@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build !(darwin || linux)
+
+package compat
+
+import (
+	"os"
+)
+
+const supportsXattr = false
+
+func listXattr(name string, _ bool) ([]string, error) {
+	return nil, xattrError("listxattr", name)
+}
+
+func flistXattr(f *os.File) ([]string, error) {
+	return nil, xattrError("flistxattr", fileName(f))
+}
+
+func getXattr(name, _ string, _ bool) ([]byte, error) {
+	return nil, xattrError("getxattr", name)
+}
+
+func fgetXattr(f *os.File, _ string) ([]byte, error) {
+	return nil, xattrError("fgetxattr", fileName(f))
+}
+
+func setXattr(name, _ string, _ []byte, _ int, _ bool) error {
+	return xattrError("setxattr", name)
+}
+
+func fsetXattr(f *os.File, _ string, _ []byte, _ int) error {
+	return xattrError("fsetxattr", fileName(f))
+}
+
+func removeXattr(name, _ string, _ bool) error {
+	return xattrError("removexattr", name)
+}
+
+func fremoveXattr(f *os.File, _ string) error {
+	return xattrError("fremovexattr", fileName(f))
+}
+
+func xattrError(op, path string) error {
+	return &os.PathError{Op: op, Path: path, Err: &UnsupportedError{Op: op}}
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+package compat_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/rasa/compat"
+)
+
+const xattrName = "user.compat.test"
+
+func TestXattr(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.SetXattr(name, xattrName, helloBytes, 0)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: extended attributes not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.GetXattr(name, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("GetXattr(): got %q, want %q", got, helloBytes)
+	}
+
+	names, err := compat.ListXattr(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(names, xattrName) {
+		t.Fatalf("ListXattr(): got %v, want %v included", names, xattrName)
+	}
+
+	err = compat.SetXattr(name, xattrName, helloBytes, compat.XattrCreate)
+	if !errors.Is(err, os.ErrExist) {
+		t.Fatalf("SetXattr(XattrCreate): got %v, want %v", err, os.ErrExist)
+	}
+
+	err = compat.RemoveXattr(name, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.GetXattr(name, xattrName)
+	if !errors.Is(err, compat.ErrNoXattr) {
+		t.Fatalf("GetXattr(): got %v, want %v", err, compat.ErrNoXattr)
+	}
+
+	err = compat.SetXattr(name, xattrName, helloBytes, compat.XattrReplace)
+	if !errors.Is(err, compat.ErrNoXattr) {
+		t.Fatalf("SetXattr(XattrReplace): got %v, want %v", err, compat.ErrNoXattr)
+	}
+
+	err = compat.RemoveXattr(name, xattrName)
+	if !errors.Is(err, compat.ErrNoXattr) {
+		t.Fatalf("RemoveXattr(): got %v, want %v", err, compat.ErrNoXattr)
+	}
+}
+
+func TestXattrEmptyValue(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.SetXattr(name, xattrName, nil, 0)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: extended attributes not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.GetXattr(name, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 0 {
+		t.Fatalf("GetXattr(): got %q, want empty", got)
+	}
+}
+
+func TestFXattr(t *testing.T) {
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	err = compat.FSetXattr(f, xattrName, helloBytes, 0)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: extended attributes not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.FGetXattr(f, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("FGetXattr(): got %q, want %q", got, helloBytes)
+	}
+
+	names, err := compat.FListXattr(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(names, xattrName) {
+		t.Fatalf("FListXattr(): got %v, want %v included", names, xattrName)
+	}
+
+	err = compat.FRemoveXattr(f, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.GetXattr(name, xattrName)
+	if !errors.Is(err, compat.ErrNoXattr) {
+		t.Fatalf("GetXattr(): got %v, want %v", err, compat.ErrNoXattr)
+	}
+}
+
+func TestLXattr(t *testing.T) {
+	if !supportsSymlinks(t) {
+		skip(t, "Skipping test: Symlinks not supported on "+runtime.GOOS)
+
+		return
+	}
+
+	target, link, err := createTempSymlink(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = compat.SetXattr(link, xattrName, helloBytes, 0)
+	if compat.IsUnsupportedError(err) {
+		skipf(t, "Skipping test: extended attributes not supported on %v: %v", runtime.GOOS, err)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := compat.GetXattr(target, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, helloBytes) {
+		t.Fatalf("GetXattr(target): got %q, want %q", got, helloBytes)
+	}
+
+	// The attribute was set on the target, not on the link.
+	names, err := compat.LListXattr(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slices.Contains(names, xattrName) {
+		t.Fatalf("LListXattr(): got %v, want %v excluded", names, xattrName)
+	}
+
+	_, err = compat.LGetXattr(link, xattrName)
+	if !errors.Is(err, compat.ErrNoXattr) {
+		t.Fatalf("LGetXattr(): got %v, want %v", err, compat.ErrNoXattr)
+	}
+
+	err = compat.RemoveXattr(link, xattrName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestXattrUnsupported(t *testing.T) {
+	if compat.SupportsXattr() {
+		skipf(t, "Skipping test: extended attributes are supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	name, err := createTempFile(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compat.ListXattr(name)
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("ListXattr(): got %v, want an UnsupportedError", err)
+	}
+
+	err = compat.LSetXattr(name, xattrName, helloBytes, 0)
+	if !compat.IsUnsupportedError(err) {
+		t.Fatalf("LSetXattr(): got %v, want an UnsupportedError", err)
+	}
+}
+
+func TestXattrNotExist(t *testing.T) {
+	if !compat.SupportsXattr() {
+		skipf(t, "Skipping test: extended attributes not supported on %v", runtime.GOOS)
+
+		return
+	}
+
+	_, err := compat.GetXattr(tempName(t), xattrName)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("GetXattr(): got %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: Copyright © 2026 Ross Smith II <ross@smithii.com>
+// SPDX-License-Identifier: MIT
+
+//go:build darwin || linux
+
+// The darwin build flag includes ios, and the linux build flag includes
+// android.
+
+package compat
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const supportsXattr = true
+
+func listXattr(name string, followSymlinks bool) ([]string, error) {
+	op, list := "listxattr", unix.Listxattr
+	if !followSymlinks {
+		op, list = "llistxattr", unix.Llistxattr
+	}
+
+	data, err := readXattr(func(dest []byte) (int, error) {
+		return list(name, dest)
+	})
+	if err != nil {
+		return nil, xattrError(op, name, err)
+	}
+
+	return splitXattrNames(data), nil
+}
+
+func flistXattr(f *os.File) ([]string, error) {
+	var data []byte
+
+	err := fdControl(f, func(fd int) error {
+		var err error
+
+		data, err = readXattr(func(dest []byte) (int, error) {
+			return unix.Flistxattr(fd, dest)
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, xattrError("flistxattr", fileName(f), err)
+	}
+
+	return splitXattrNames(data), nil
+}
+
+func getXattr(name, attr string, followSymlinks bool) ([]byte, error) {
+	op, get := "getxattr", unix.Getxattr
+	if !followSymlinks {
+		op, get = "lgetxattr", unix.Lgetxattr
+	}
+
+	data, err := readXattr(func(dest []byte) (int, error) {
+		return get(name, attr, dest)
+	})
+	if err != nil {
+		return nil, xattrError(op, name, err)
+	}
+
+	return data, nil
+}
+
+func fgetXattr(f *os.File, attr string) ([]byte, error) {
+	var data []byte
+
+	err := fdControl(f, func(fd int) error {
+		var err error
+
+		data, err = readXattr(func(dest []byte) (int, error) {
+			return unix.Fgetxattr(fd, attr, dest)
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, xattrError("fgetxattr", fileName(f), err)
+	}
+
+	return data, nil
+}
+
+func setXattr(name, attr string, data []byte, flags int, followSymlinks bool) error {
+	op, set := "setxattr", unix.Setxattr
+	if !followSymlinks {
+		op, set = "lsetxattr", unix.Lsetxattr
+	}
+
+	err := set(name, attr, data, xattrFlags(flags))
+	if err != nil {
+		return xattrError(op, name, err)
+	}
+
+	return nil
+}
+
+func fsetXattr(f *os.File, attr string, data []byte, flags int) error {
+	err := fdControl(f, func(fd int) error {
+		return unix.Fsetxattr(fd, attr, data, xattrFlags(flags))
+	})
+	if err != nil {
+		return xattrError("fsetxattr", fileName(f), err)
+	}
+
+	return nil
+}
+
+func removeXattr(name, attr string, followSymlinks bool) error {
+	op, remove := "removexattr", unix.Removexattr
+	if !followSymlinks {
+		op, remove = "lremovexattr", unix.Lremovexattr
+	}
+
+	err := remove(name, attr)
+	if err != nil {
+		return xattrError(op, name, err)
+	}
+
+	return nil
+}
+
+func fremoveXattr(f *os.File, attr string) error {
+	err := fdControl(f, func(fd int) error {
+		return unix.Fremovexattr(fd, attr)
+	})
+	if err != nil {
+		return xattrError("fremovexattr", fileName(f), err)
+	}
+
+	return nil
+}
+
+// readXattr calls fn with a nil buffer to get the value's size, then with a
+// buffer of that size, retrying if the value grew in between.
+func readXattr(fn func(dest []byte) (int, error)) ([]byte, error) {
+	for {
+		size, err := fn(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if size == 0 {
+			return []byte{}, nil
+		}
+
+		buf := make([]byte, size)
+
+		size, err = fn(buf)
+		if errors.Is(err, unix.ERANGE) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return buf[:size], nil
+	}
+}
+
+// splitXattrNames splits a list of NUL terminated names.
+func splitXattrNames(data []byte) []string {
+	names := make([]string, 0, bytes.Count(data, []byte{0}))
+
+	for name := range bytes.SplitSeq(data, []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+
+	return names
+}
+
+func xattrFlags(flags int) int {
+	f := 0
+
+	if flags&XattrCreate != 0 {
+		f |= unix.XATTR_CREATE
+	}
+
+	if flags&XattrReplace != 0 {
+		f |= unix.XATTR_REPLACE
+	}
+
+	return f
+}
+
+// xattrError maps the errno values that mean the attribute doesn't exist, or
+// the filesystem doesn't support extended attributes, to portable errors.
+func xattrError(op, path string, err error) error {
+	switch {
+	case errors.Is(err, errNoXattr):
+		err = ErrNoXattr
+	case errors.Is(err, unix.ENOTSUP), errors.Is(err, unix.EOPNOTSUPP):
+		err = &UnsupportedError{Op: op}
+	}
+
+	return &os.PathError{Op: op, Path: path, Err: err}
+}
+
+// fdControl calls fn with f's file descriptor.
+func fdControl(f *os.File, fn func(fd int) error) error {
+	if f == nil {
+		return os.ErrInvalid
+	}
+
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var ferr error
+
+	err = conn.Control(func(fd uintptr) {
+		ferr = fn(int(fd)) //nolint:gosec // intentional uintptr → int conversion
+	})
+	if err != nil {
+		return err
+	}
+
+	return ferr
+}